@@ -1,8 +1,9 @@
 package ravepay
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"fmt"
 )
 
 // Account is a type that encapsulates rave's account description
@@ -28,6 +29,26 @@ type Account struct {
 	UpdatedAt                string      `json:"updatedAt"`
 }
 
+// Redacted returns a copy of the account with its passcode masked
+// It is safe to log or encode as json, unlike the account itself whose json is the charge payload
+func (a Account) Redacted() Account {
+	a.Passcode = redactSecret(a.Passcode)
+	return a
+}
+
+// account has the fields of Account without its methods, for formatting it without recursing into String
+type account Account
+
+// String formats the account with its passcode masked
+func (a Account) String() string {
+	return fmt.Sprintf("%+v", account(a.Redacted()))
+}
+
+// GoString formats the account in go syntax with its passcode masked
+func (a Account) GoString() string {
+	return goString("Account", account(a.Redacted()))
+}
+
 // ChargeURL is an implemenation of the Chargeable interface
 // it returns the url to be used for charging the given account
 func (a *Account) ChargeURL() string {
@@ -60,7 +81,29 @@ func (a *Account) BuildChargeRequestPayload(creq *ChargeRequest) []byte {
 	}{a, creq}
 	b, err := json.Marshal(payload)
 	if err != nil {
-		log.Println("couldn't marshal payload: ", err)
+		logf(context.Background(), LogLevelError, "couldn't marshal payload", "err", err)
 	}
 	return b
 }
+
+// accountMarkets are the bank accounts rave can debit
+var accountMarkets = []market{{"NG", "NGN"}, {"ZA", "ZAR"}, {"GB", "GBP"}, {"US", "USD"}}
+
+// Validate checks the account has a bank and account number
+// and that its country and currency are ones rave can debit accounts in
+func (a *Account) Validate() error {
+	var errs ValidationErrors
+	if a.AccountBank == "" {
+		errs = append(errs, &ValidationError{Field: "account_bank", Message: "is required"})
+	}
+	if a.AccountNumber == "" || !isDigits(a.AccountNumber, len(a.AccountNumber)) {
+		errs = append(errs, &ValidationError{Field: "account_number", Message: "must be digits"})
+	}
+	errs = errs.add("", validateMarket("account", a.Country, a.Currency, accountMarkets))
+	return errs.err()
+}
+
+// validateCharge checks the charge amount is valid in the account's currency
+func (a *Account) validateCharge(creq *ChargeRequest) error {
+	return validateAmountPrecision(creq.Amount, a.Currency)
+}
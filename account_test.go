@@ -1,6 +1,11 @@
 package ravepay
 
-import "testing"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
 
 func TestAccount_ChargeURL(t *testing.T) {
 	type fields struct {
@@ -71,3 +76,20 @@ func TestAccount_ValidateChargeURL(t *testing.T) {
 		})
 	}
 }
+
+func TestAccount_Redacted(t *testing.T) {
+	a := Account{AccountBank: "044", AccountNumber: "0690000031", Passcode: "09101989"}
+
+	b, _ := json.Marshal(a.Redacted())
+	for _, got := range []string{fmt.Sprint(a), fmt.Sprintf("%#v", &a), string(b)} {
+		if strings.Contains(got, "09101989") || !strings.Contains(got, "0690000031") {
+			t.Errorf("formatted account %s, want the passcode masked", got)
+		}
+	}
+
+	var payload map[string]interface{}
+	json.Unmarshal(a.BuildChargeRequestPayload(&ChargeRequest{}), &payload)
+	if payload["passcode"] != "09101989" {
+		t.Errorf("Account.BuildChargeRequestPayload() = %v, want the passcode", payload)
+	}
+}
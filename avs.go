@@ -0,0 +1,69 @@
+package ravepay
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AVSResult is the result of the issuer's address verification (AVS) of the card holder's billing address
+// Code is the standard AVS result code e.g Y for a full match, N for no match
+type AVSResult struct {
+	Code    string
+	Message string
+}
+
+// newAVSResult returns the avs result for rave's avsresponsecode and avsresponsemessage
+// rave sends null for both on charges without address verification
+func newAVSResult(code, message interface{}) AVSResult {
+	r := AVSResult{}
+	if code != nil {
+		r.Code = strings.ToUpper(strings.TrimSpace(fmt.Sprint(code)))
+	}
+	if message != nil {
+		r.Message = fmt.Sprint(message)
+	}
+	return r
+}
+
+// Available reports whether the issuer verified the address
+// it is false for charges without address verification, and issuers that don't support it
+func (r AVSResult) Available() bool {
+	switch r.Code {
+	case "", "U", "R", "S", "G", "E":
+		return false
+	}
+	return true
+}
+
+// AddressMatched reports whether the billing street address matched the issuer's record
+func (r AVSResult) AddressMatched() bool {
+	switch r.Code {
+	case "Y", "X", "A", "B", "D", "M":
+		return true
+	}
+	return false
+}
+
+// ZipMatched reports whether the billing zip matched the issuer's record
+func (r AVSResult) ZipMatched() bool {
+	switch r.Code {
+	case "Y", "X", "W", "Z", "P", "D", "M":
+		return true
+	}
+	return false
+}
+
+// AVS returns the address verification result of the preauth refund or void
+func (p *PreAuthResponse) AVS() AVSResult {
+	return newAVSResult(p.Data.Data.Avsresponsecode, p.Data.Data.Avsresponsemessage)
+}
+
+// AVS returns the address verification result of the charge
+func (cr *ChargeResponse) AVS() AVSResult {
+	return newAVSResult(cr.Data.Avsresponsecode, cr.Data.Avsresponsemessage)
+}
+
+// AVS returns the address verification result of the validated charge
+func (cv *ChargeValidationResponse) AVS() AVSResult {
+	return newAVSResult(cv.Data.Tx.Avsresponsecode, cv.Data.Tx.Avsresponsemessage)
+}
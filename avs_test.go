@@ -0,0 +1,71 @@
+package ravepay
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAVSResult(t *testing.T) {
+	tests := []struct {
+		code                                string
+		wantAvailable, wantAddress, wantZip bool
+	}{
+		{code: "Y", wantAvailable: true, wantAddress: true, wantZip: true},
+		{code: "A", wantAvailable: true, wantAddress: true},
+		{code: "Z", wantAvailable: true, wantZip: true},
+		{code: "N", wantAvailable: true},
+		{code: "U"},
+		{code: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			r := AVSResult{Code: tt.code}
+			if r.Available() != tt.wantAvailable || r.AddressMatched() != tt.wantAddress || r.ZipMatched() != tt.wantZip {
+				t.Errorf("AVSResult{%s} = available %v, address %v, zip %v", tt.code, r.Available(), r.AddressMatched(), r.ZipMatched())
+			}
+		})
+	}
+}
+
+func TestPreAuthResponse_AVS(t *testing.T) {
+	resp := &PreAuthResponse{}
+	json.Unmarshal([]byte(successfulPreAuthPaymentRefundResponse), resp)
+	if avs := resp.AVS(); avs != (AVSResult{}) {
+		t.Errorf("PreAuthResponse.AVS() = %+v, want no result for a charge without address verification", avs)
+	}
+
+	resp.Data.Data.Avsresponsecode = "y"
+	resp.Data.Data.Avsresponsemessage = "Address and zip match"
+	if avs := resp.AVS(); avs != (AVSResult{Code: "Y", Message: "Address and zip match"}) || !avs.AddressMatched() {
+		t.Errorf("PreAuthResponse.AVS() = %+v, want a full match", avs)
+	}
+}
+
+func TestChargeResponse_AVS(t *testing.T) {
+	resp := &ChargeResponse{}
+	if err := json.Unmarshal([]byte(successfulCardChargeResponse), resp); err != nil {
+		t.Fatal(err)
+	}
+	if avs := resp.AVS(); avs != (AVSResult{}) {
+		t.Errorf("ChargeResponse.AVS() = %+v, want no result for a charge without address verification", avs)
+	}
+
+	body := `{"status":"success","message":"V-COMP","data":{"flwRef":"FLW-MOCK-1","avsresponsecode":"z","avsresponsemessage":"Zip matches, address doesn't"}}`
+	if err := json.Unmarshal([]byte(body), resp); err != nil {
+		t.Fatal(err)
+	}
+	if avs := resp.AVS(); avs != (AVSResult{Code: "Z", Message: "Zip matches, address doesn't"}) || avs.AddressMatched() || !avs.ZipMatched() {
+		t.Errorf("ChargeResponse.AVS() = %+v, want a zip match", avs)
+	}
+}
+
+func TestChargeValidationResponse_AVS(t *testing.T) {
+	resp := &ChargeValidationResponse{}
+	body := `{"status":"success","message":"Charge Complete","data":{"data":{"responsecode":"00","responsemessage":"successful"},"tx":{"flwRef":"FLW-MOCK-1","avsresponsecode":"N","avsresponsemessage":"No match"}}}`
+	if err := json.Unmarshal([]byte(body), resp); err != nil {
+		t.Fatal(err)
+	}
+	if avs := resp.AVS(); avs != (AVSResult{Code: "N", Message: "No match"}) || !avs.Available() || avs.AddressMatched() || avs.ZipMatched() {
+		t.Errorf("ChargeValidationResponse.AVS() = %+v, want no match", avs)
+	}
+}
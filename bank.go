@@ -1,5 +1,7 @@
 package ravepay
 
+import "context"
+
 // Bank is a type of rave bank resources
 type Bank struct {
 	Code            string `json:"bankcode"`
@@ -13,8 +15,31 @@ var banksURL = buildURL(listBanksURL)
 // ListBanks returns list of banks from the rave api
 // https://flutterwavedevelopers.readme.io/v1.0/reference#list-of-banks
 func ListBanks() ([]Bank, error) {
-	banks := []Bank{}
+	return ListBanksContext(context.Background())
+}
+
+// ListBanksContext is like ListBanks but the request is bound to the given context
+func ListBanksContext(ctx context.Context) ([]Bank, error) {
+	return defaultClient().listBanks(ctx, banksURL)
+}
+
+// ListBanks returns list of banks from the rave api
+// https://flutterwavedevelopers.readme.io/v1.0/reference#list-of-banks
+func (c *Client) ListBanks() ([]Bank, error) {
+	return c.ListBanksContext(context.Background())
+}
+
+// ListBanksContext is like ListBanks but the request is bound to the given context
+func (c *Client) ListBanksContext(ctx context.Context) ([]Bank, error) {
+	return c.listBanks(ctx, c.buildURL(listBanksURL))
+}
+
+func (c *Client) listBanks(ctx context.Context, url string) ([]Bank, error) {
+	var banks []Bank
 
-	err := sendRequestAndParseResponse("GET", banksURL, nil, &banks)
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		banks = []Bank{}
+		return c.sendRequestAndParseResponse(ctx, "GET", url, nil, &banks)
+	}, nil)
 	return banks, err
 }
@@ -0,0 +1,75 @@
+package ravepay
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// BINInfo is rave's description of the cards issued under a BIN
+type BINInfo struct {
+	BIN        string `json:"bin"`
+	CardType   string `json:"card_type"`
+	IssuerInfo string `json:"issuer_info"`
+	// IssuingCountry is the issuer's country name followed by its code e.g NIGERIA NG
+	IssuingCountry string `json:"issuing_country"`
+}
+
+// CountryCode returns the two letter code of the issuing country, or an empty string if rave didn't return it
+func (b BINInfo) CountryCode() string {
+	fields := strings.Fields(b.IssuingCountry)
+	if len(fields) < 2 || len(fields[len(fields)-1]) != 2 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+// BINResponse is a type of rave response for a BIN lookup
+type BINResponse struct {
+	Data    BINInfo `json:"data"`
+	Message string  `json:"message"`
+	Status  string  `json:"status"`
+}
+
+// BIN returns the first six digits of the card number, the BIN to look the card up by
+func (c *Card) BIN() string {
+	if len(c.CardNo) < 6 {
+		return c.CardNo
+	}
+	return c.CardNo[:6]
+}
+
+// LookupBIN returns the issuer, card type and issuing country of the cards under the BIN
+// The BIN is the first 6 to 8 digits of a card number
+func LookupBIN(bin string) (*BINResponse, error) {
+	return LookupBINContext(context.Background(), bin)
+}
+
+// LookupBINContext is like LookupBIN but the request is bound to the given context
+func LookupBINContext(ctx context.Context, bin string) (*BINResponse, error) {
+	return defaultClient().LookupBINContext(ctx, bin)
+}
+
+// LookupBIN returns the issuer, card type and issuing country of the cards under the BIN
+// The BIN is the first 6 to 8 digits of a card number
+func (c *Client) LookupBIN(bin string) (*BINResponse, error) {
+	return c.LookupBINContext(context.Background(), bin)
+}
+
+// LookupBINContext is like LookupBIN but the request is bound to the given context
+func (c *Client) LookupBINContext(ctx context.Context, bin string) (*BINResponse, error) {
+	resp := &BINResponse{}
+	// only the BIN is sent so card numbers don't leak into request urls
+	if !isDigits(bin, 6) && !isDigits(bin, 7) && !isDigits(bin, 8) {
+		return resp, &ValidationError{Field: "bin", Message: "must be 6 to 8 digits"}
+	}
+
+	query := url.Values{"seckey": {c.SecretKey}}
+	endpoint := c.buildURL(fmt.Sprintf(lookupBINURL, bin)) + "?" + query.Encode()
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = BINResponse{}
+		return c.sendRequestAndParseResponse(ctx, "GET", endpoint, nil, resp)
+	}, nil)
+	return resp, err
+}
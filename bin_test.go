@@ -0,0 +1,55 @@
+package ravepay
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_LookupBIN(t *testing.T) {
+	handler := &transferTestServer{resp: binResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	card := &Card{CardNo: "5399830000000000"}
+	got, err := c.LookupBIN(card.BIN())
+	if err != nil {
+		t.Fatalf("Client.LookupBIN() error = %v", err)
+	}
+	if wantPath := fmt.Sprintf(lookupBINURL, "539983"); handler.path != wantPath || handler.query["seckey"] != "sec-key" {
+		t.Errorf("request = %s %v, want %s", handler.path, handler.query, wantPath)
+	}
+	if got.Data.CardType != "MASTERCARD" || got.Data.IssuerInfo != "GTBANK" || got.Data.CountryCode() != "NG" {
+		t.Errorf("Client.LookupBIN() = %+v", got.Data)
+	}
+
+	for _, bin := range []string{"53998", "5399830000000000", "53998a"} {
+		handler.path = ""
+		if _, err := c.LookupBIN(bin); !errors.Is(err, ErrValidation) || handler.path != "" {
+			t.Errorf("Client.LookupBIN(%s) error = %v, request path = %s, want a ValidationError without a request", bin, err, handler.path)
+		}
+	}
+}
+
+func TestBINInfo_CountryCode(t *testing.T) {
+	tests := []struct {
+		country string
+		want    string
+	}{
+		{country: "NIGERIA NG", want: "NG"},
+		{country: "UNITED STATES US", want: "US"},
+		{country: "NIGERIA", want: ""},
+		{country: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.country, func(t *testing.T) {
+			if got := (BINInfo{IssuingCountry: tt.country}).CountryCode(); got != tt.want {
+				t.Errorf("BINInfo.CountryCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var binResponse = `{"status":"success","message":"BIN-FETCHED","data":{"issuing_country":"NIGERIA NG","bin":"539983","card_type":"MASTERCARD","issuer_info":"GTBANK"}}`
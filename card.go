@@ -1,18 +1,27 @@
 package ravepay
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"fmt"
+	"strings"
 )
 
 // Card is a type that encapsulates rave's card description
 // It has all card attributes necessary for rave api card references
 // It also implements the chargable interface required for making charge requests and validating them
 type Card struct {
-	Brand      string `json:"brand,omitempty"`
-	CardBIN    string `json:"cardBIN,omitempty"`
-	CardNo     string `json:"cardno"`
-	CardTokens []struct {
+	// BillingAddress, BillingCity, BillingCountry, BillingState and BillingZip are the card holder's billing address
+	// They are required for international cards rave suggests the NOAUTH_INTERNATIONAL or AVS_VBVSECURECODE auth for
+	BillingAddress string `json:"billingaddress,omitempty"`
+	BillingCity    string `json:"billingcity,omitempty"`
+	BillingCountry string `json:"billingcountry,omitempty"`
+	BillingState   string `json:"billingstate,omitempty"`
+	BillingZip     string `json:"billingzip,omitempty"`
+	Brand          string `json:"brand,omitempty"`
+	CardBIN        string `json:"cardBIN,omitempty"`
+	CardNo         string `json:"cardno"`
+	CardTokens     []struct {
 		Embedtoken string `json:"embedtoken"`
 		Shortcode  string `json:"shortcode"`
 	} `json:"card_tokens,omitempty"`
@@ -25,10 +34,52 @@ type Card struct {
 	FirstName             string `json:"firstname,omitempty"`
 	Last4digits           string `json:"last4digits,omitempty"`
 	LastName              string `json:"lastname,omitempty"`
+	LifeTimeToken         string `json:"life_time_token,omitempty"`
 	Pin                   string `json:"pin"`
 	ValidateCardChargeURL string `json:"-"`
 }
 
+// Redacted returns a copy of the card with the card number, cvv and pin masked
+// The card number keeps its first 6 and last 4 digits
+// It is safe to log or encode as json, unlike the card itself whose json is the charge payload
+func (c Card) Redacted() Card {
+	c.CardNo = redactPAN(c.CardNo)
+	c.Cvv = redactSecret(c.Cvv)
+	c.Pin = redactSecret(c.Pin)
+	return c
+}
+
+// card has the fields of Card without its methods, for formatting it without recursing into String
+type card Card
+
+// String formats the card with its card number, cvv and pin masked
+func (c Card) String() string {
+	return fmt.Sprintf("%+v", card(c.Redacted()))
+}
+
+// GoString formats the card in go syntax with its card number, cvv and pin masked
+func (c Card) GoString() string {
+	return goString("Card", card(c.Redacted()))
+}
+
+// BillingAddress is the card holder's billing address
+type BillingAddress struct {
+	Address string
+	City    string
+	Country string
+	State   string
+	Zip     string
+}
+
+// SetBillingAddress sets the card holder's billing address on the card
+func (c *Card) SetBillingAddress(a BillingAddress) {
+	c.BillingAddress = a.Address
+	c.BillingCity = a.City
+	c.BillingCountry = a.Country
+	c.BillingState = a.State
+	c.BillingZip = a.Zip
+}
+
 // ChargeURL is an implemenation of the Chargeable interface
 // it returns the url to be used for charging the given card
 func (c *Card) ChargeURL() string {
@@ -61,7 +112,41 @@ func (c *Card) BuildChargeRequestPayload(creq *ChargeRequest) []byte {
 	}{c, creq}
 	b, err := json.Marshal(payload)
 	if err != nil {
-		log.Println("couldn't marshal payload: ", err)
+		logf(context.Background(), LogLevelError, "couldn't marshal payload", "err", err)
 	}
 	return b
 }
+
+// requiresBillingAddress reports whether the suggested auth model requires the card holder's billing address
+func requiresBillingAddress(suggestedAuth string) bool {
+	switch strings.ToUpper(suggestedAuth) {
+	case SuggestedAuthNoAuthInternational, SuggestedAuthAVSVBVSecureCode:
+		return true
+	}
+	return false
+}
+
+// validateCharge checks the charge amount is valid in the card's currency
+// and that the card has the billing address if the charge's suggested auth requires it
+func (c *Card) validateCharge(creq *ChargeRequest) error {
+	if err := validateAmountPrecision(creq.Amount, c.Currency); err != nil {
+		return err
+	}
+	if !requiresBillingAddress(creq.SuggestedAuth) {
+		return nil
+	}
+
+	fields := []struct{ field, value string }{
+		{"billingzip", c.BillingZip},
+		{"billingcity", c.BillingCity},
+		{"billingaddress", c.BillingAddress},
+		{"billingstate", c.BillingState},
+		{"billingcountry", c.BillingCountry},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			return &ValidationError{Field: f.field, Message: "is required for " + strings.ToUpper(creq.SuggestedAuth) + " auth"}
+		}
+	}
+	return nil
+}
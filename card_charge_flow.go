@@ -0,0 +1,214 @@
+package ravepay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CardChargeAction is the next action required to complete a card charge
+type CardChargeAction string
+
+// The actions a card charge goes through
+// CardChargeActionRedirect, CardChargeActionDone and CardChargeActionFailed end the flow
+// on redirect, the customer completes the charge on the bank's page at the flow's AuthURL
+const (
+	CardChargeActionStart          CardChargeAction = "start"
+	CardChargeActionPIN            CardChargeAction = "supply_pin"
+	CardChargeActionBillingAddress CardChargeAction = "supply_billing_address"
+	CardChargeActionOTP            CardChargeAction = "enter_otp"
+	CardChargeActionRedirect       CardChargeAction = "redirect"
+	CardChargeActionDone           CardChargeAction = "done"
+	CardChargeActionFailed         CardChargeAction = "failed"
+)
+
+// Suggested auth models rave responds with when a card charge needs more information
+const (
+	SuggestedAuthPIN                 = "PIN"
+	SuggestedAuthNoAuthInternational = "NOAUTH_INTERNATIONAL"
+	SuggestedAuthAVSVBVSecureCode    = "AVS_VBVSECURECODE"
+)
+
+// CardChargeFlow drives a card charge through rave's suggested auth steps
+// Each step returns the next action required of the caller, and the flow only accepts the input for that action
+// e.g a charge rave suggests PIN auth for returns CardChargeActionPIN, and continues with SupplyPIN
+// A step that fails without rave failing the charge, e.g on a transport error, can be retried
+// The flow updates the charge request and card it is created with as it goes
+type CardChargeFlow struct {
+	Request *ChargeRequest
+	Card    *Card
+
+	client     *Client
+	action     CardChargeAction
+	resp       *ChargeResponse
+	validation *ChargeValidationResponse
+}
+
+// NewCardChargeFlow returns a flow for charging the card with the charge request
+func NewCardChargeFlow(cr *ChargeRequest, card *Card) *CardChargeFlow {
+	return defaultClient().NewCardChargeFlow(cr, card)
+}
+
+// NewCardChargeFlow returns a flow for charging the card with the charge request using the client
+func (c *Client) NewCardChargeFlow(cr *ChargeRequest, card *Card) *CardChargeFlow {
+	return &CardChargeFlow{
+		Request: cr,
+		Card:    card,
+		client:  c,
+		action:  CardChargeActionStart,
+	}
+}
+
+// Action returns the next action required to complete the charge
+func (f *CardChargeFlow) Action() CardChargeAction {
+	return f.action
+}
+
+// Response returns the latest charge response, it is nil until the flow is started
+func (f *CardChargeFlow) Response() *ChargeResponse {
+	return f.resp
+}
+
+// ValidationResponse returns the otp validation response, it is nil until an otp is submitted
+func (f *CardChargeFlow) ValidationResponse() *ChargeValidationResponse {
+	return f.validation
+}
+
+// AuthURL returns the bank page to redirect the customer to when the action is CardChargeActionRedirect
+func (f *CardChargeFlow) AuthURL() string {
+	if f.resp == nil {
+		return ""
+	}
+	return f.resp.Data.Authurl
+}
+
+// Start makes the initial charge request
+func (f *CardChargeFlow) Start() (CardChargeAction, error) {
+	return f.StartContext(context.Background())
+}
+
+// StartContext is like Start but the request is bound to the given context
+func (f *CardChargeFlow) StartContext(ctx context.Context) (CardChargeAction, error) {
+	if err := f.expect(CardChargeActionStart); err != nil {
+		return f.action, err
+	}
+	return f.charge(ctx)
+}
+
+// SupplyPIN charges the card again with its pin
+func (f *CardChargeFlow) SupplyPIN(pin string) (CardChargeAction, error) {
+	return f.SupplyPINContext(context.Background(), pin)
+}
+
+// SupplyPINContext is like SupplyPIN but the request is bound to the given context
+func (f *CardChargeFlow) SupplyPINContext(ctx context.Context, pin string) (CardChargeAction, error) {
+	if err := f.expect(CardChargeActionPIN); err != nil {
+		return f.action, err
+	}
+
+	f.Card.Pin = pin
+	f.Request.SuggestedAuth = SuggestedAuthPIN
+	return f.charge(ctx)
+}
+
+// SupplyBillingAddress charges the card again with the card holder's billing address
+func (f *CardChargeFlow) SupplyBillingAddress(address BillingAddress) (CardChargeAction, error) {
+	return f.SupplyBillingAddressContext(context.Background(), address)
+}
+
+// SupplyBillingAddressContext is like SupplyBillingAddress but the request is bound to the given context
+func (f *CardChargeFlow) SupplyBillingAddressContext(ctx context.Context, address BillingAddress) (CardChargeAction, error) {
+	if err := f.expect(CardChargeActionBillingAddress); err != nil {
+		return f.action, err
+	}
+
+	f.Card.SetBillingAddress(address)
+	f.Request.SuggestedAuth = strings.ToUpper(f.resp.Data.SuggestedAuth)
+	return f.charge(ctx)
+}
+
+// SubmitOTP validates the charge with the otp sent to the card holder
+func (f *CardChargeFlow) SubmitOTP(otp string) (CardChargeAction, error) {
+	return f.SubmitOTPContext(context.Background(), otp)
+}
+
+// SubmitOTPContext is like SubmitOTP but the request is bound to the given context
+func (f *CardChargeFlow) SubmitOTPContext(ctx context.Context, otp string) (CardChargeAction, error) {
+	if err := f.expect(CardChargeActionOTP); err != nil {
+		return f.action, err
+	}
+
+	validation, err := f.client.OTPValidationContext(ctx, f.resp, otp)
+	f.validation = validation
+	if err != nil {
+		if chargeFailed(err) {
+			f.action = CardChargeActionFailed
+		}
+		return f.action, err
+	}
+
+	f.action = CardChargeActionFailed
+	if validation.Data.Tx.ChargeResponseCode == "00" || strings.EqualFold(validation.Data.Tx.Status, "successful") {
+		f.action = CardChargeActionDone
+	}
+	return f.action, nil
+}
+
+func (f *CardChargeFlow) expect(action CardChargeAction) error {
+	if f.action != action {
+		return fmt.Errorf("CardChargeFlowInvalidStep: the next action is %s, not %s", f.action, action)
+	}
+	return nil
+}
+
+func (f *CardChargeFlow) charge(ctx context.Context) (CardChargeAction, error) {
+	resp, err := f.client.ChargeContext(ctx, f.Request, f.Card)
+	if err != nil {
+		if chargeFailed(err) {
+			f.resp = resp
+			f.action = CardChargeActionFailed
+		}
+		return f.action, err
+	}
+
+	f.resp = resp
+	f.action = nextCardChargeAction(resp)
+	return f.action, nil
+}
+
+// chargeFailed reports whether rave failed the charge
+// other errors, like transport errors, leave the step to be retried
+func chargeFailed(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Err == nil && !errors.Is(err, ErrServer) && !errors.Is(err, ErrRateLimited)
+}
+
+// nextCardChargeAction returns the action the charge response requires
+func nextCardChargeAction(resp *ChargeResponse) CardChargeAction {
+	data := resp.Data
+	switch strings.ToUpper(data.SuggestedAuth) {
+	case SuggestedAuthPIN:
+		return CardChargeActionPIN
+	case SuggestedAuthNoAuthInternational, SuggestedAuthAVSVBVSecureCode:
+		return CardChargeActionBillingAddress
+	}
+
+	switch data.ChargeResponseCode {
+	case "00":
+		return CardChargeActionDone
+	case "02":
+		// pin and otp auth models are validated with an otp even though rave also returns an auth url for them
+		authModel := strings.ToUpper(data.AuthModelUsed)
+		if hasAuthURL(data.Authurl) && authModel != SuggestedAuthPIN && !strings.HasSuffix(authModel, "OTP") {
+			return CardChargeActionRedirect
+		}
+		return CardChargeActionOTP
+	}
+	return CardChargeActionFailed
+}
+
+// hasAuthURL reports whether the auth url is a url rather than one of rave's placeholders e.g N/A, NO-URL
+func hasAuthURL(authURL string) bool {
+	return strings.HasPrefix(authURL, "http")
+}
@@ -0,0 +1,132 @@
+package ravepay
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type flowTestServer struct {
+	resps []string
+	calls []string
+}
+
+func (ts *flowTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ts.calls = append(ts.calls, r.URL.Path)
+	resp := ts.resps[0]
+	ts.resps = ts.resps[1:]
+	w.Write([]byte(resp))
+}
+
+func Test_nextCardChargeAction(t *testing.T) {
+	tests := []struct {
+		name string
+		data chargeResponseData
+		want CardChargeAction
+	}{
+		{name: "asks for the pin", data: chargeResponseData{SuggestedAuth: "PIN"}, want: CardChargeActionPIN},
+		{name: "asks for the billing address for international cards", data: chargeResponseData{SuggestedAuth: "NOAUTH_INTERNATIONAL"}, want: CardChargeActionBillingAddress},
+		{name: "asks for the billing address for avs", data: chargeResponseData{SuggestedAuth: "AVS_VBVSECURECODE"}, want: CardChargeActionBillingAddress},
+		{name: "asks for the otp of pin charges", data: chargeResponseData{ChargeResponseCode: "02", AuthModelUsed: "PIN", Authurl: "http://flw-pms-dev.eu-west-1.elasticbeanstalk.com/mockvbvpage"}, want: CardChargeActionOTP},
+		{name: "asks for the otp without an auth url", data: chargeResponseData{ChargeResponseCode: "02", AuthModelUsed: "ACCESS_OTP", Authurl: "N/A"}, want: CardChargeActionOTP},
+		{name: "redirects to the auth url", data: chargeResponseData{ChargeResponseCode: "02", AuthModelUsed: "VBVSECURECODE", Authurl: "https://bank.example.com/3ds"}, want: CardChargeActionRedirect},
+		{name: "completes approved charges", data: chargeResponseData{ChargeResponseCode: "00"}, want: CardChargeActionDone},
+		{name: "fails other charges", data: chargeResponseData{ChargeResponseCode: "RR"}, want: CardChargeActionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextCardChargeAction(&ChargeResponse{Data: tt.data}); got != tt.want {
+				t.Errorf("nextCardChargeAction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardChargeFlow_pin(t *testing.T) {
+	handler := &flowTestServer{resps: []string{suggestPINChargeResponse, successfulCardChargeResponse, successfulValidateChargeCardResponse}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys(PublicKey, SecretKey))
+	cr := &ChargeRequest{Amount: NewMoney(30000, ""), Email: "tester@flutter.co", TxRef: "MXX-ASC-4578"}
+	card := &Card{CardNo: "5438898014560229", Cvv: "789", Expirymonth: "09", Expiryyear: testExpiryYear, ChargeCardURL: server.URL + "/charge", ValidateCardChargeURL: server.URL + "/validate"}
+	flow := c.NewCardChargeFlow(cr, card)
+
+	if _, err := flow.SubmitOTP("12345"); err == nil || flow.Action() != CardChargeActionStart {
+		t.Errorf("CardChargeFlow.SubmitOTP() before starting = %v, %v, want an error and no change", flow.Action(), err)
+	}
+
+	steps := []struct {
+		step func() (CardChargeAction, error)
+		want CardChargeAction
+	}{
+		{step: flow.Start, want: CardChargeActionPIN},
+		{step: func() (CardChargeAction, error) { return flow.SupplyPIN("3310") }, want: CardChargeActionOTP},
+		{step: func() (CardChargeAction, error) { return flow.SubmitOTP("12345") }, want: CardChargeActionDone},
+	}
+	for i, s := range steps {
+		got, err := s.step()
+		if err != nil || got != s.want {
+			t.Fatalf("step %d = %v, %v, want %v", i, got, err, s.want)
+		}
+	}
+
+	if card.Pin != "3310" || cr.SuggestedAuth != SuggestedAuthPIN {
+		t.Errorf("the flow charged with pin %q and suggested auth %q, want 3310 and PIN", card.Pin, cr.SuggestedAuth)
+	}
+	if len(handler.calls) != 3 || handler.calls[2] != "/validate" {
+		t.Errorf("the flow made requests to %v", handler.calls)
+	}
+	if flow.ValidationResponse().EmbedToken() == "" {
+		t.Errorf("CardChargeFlow.ValidationResponse() = %+v, want the validated charge", flow.ValidationResponse())
+	}
+}
+
+func TestCardChargeFlow_billingAddress(t *testing.T) {
+	handler := &flowTestServer{resps: []string{suggestNoAuthInternationalChargeResponse, redirectCardChargeResponse}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys(PublicKey, SecretKey))
+	cr := &ChargeRequest{Amount: NewMoney(30000, ""), Email: "tester@flutter.co", TxRef: "MXX-ASC-4578"}
+	card := &Card{CardNo: "4556052704172643", Cvv: "789", Expirymonth: "09", Expiryyear: testExpiryYear, ChargeCardURL: server.URL}
+	flow := c.NewCardChargeFlow(cr, card)
+
+	if got, err := flow.Start(); err != nil || got != CardChargeActionBillingAddress {
+		t.Fatalf("CardChargeFlow.Start() = %v, %v, want %v", got, err, CardChargeActionBillingAddress)
+	}
+	if _, err := flow.SupplyPIN("3310"); err == nil {
+		t.Errorf("CardChargeFlow.SupplyPIN() error = nil, want an error for the unexpected step")
+	}
+
+	got, err := flow.SupplyBillingAddress(BillingAddress{Zip: "07205", City: "Hillside", Address: "470 Mundet PI", State: "NJ", Country: "US"})
+	if err != nil || got != CardChargeActionRedirect {
+		t.Fatalf("CardChargeFlow.SupplyBillingAddress() = %v, %v, want %v", got, err, CardChargeActionRedirect)
+	}
+	if card.BillingZip != "07205" || card.BillingCountry != "US" {
+		t.Errorf("the flow charged the card with billing address %+v", card)
+	}
+	if cr.SuggestedAuth != SuggestedAuthNoAuthInternational || flow.AuthURL() != "https://bank.example.com/3ds?ref=FLW-MOCK-1" {
+		t.Errorf("the flow charged with suggested auth %q and redirects to %q", cr.SuggestedAuth, flow.AuthURL())
+	}
+}
+
+func TestCardChargeFlow_declined(t *testing.T) {
+	handler := &flowTestServer{resps: []string{declinedCardChargeResponse}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	cr := &ChargeRequest{Amount: NewMoney(30000, ""), Email: "tester@flutter.co", TxRef: "MXX-ASC-4578"}
+	flow := NewClient(WithKeys(PublicKey, SecretKey)).NewCardChargeFlow(cr, &Card{CardNo: "5438898014560229", Cvv: "789", Expirymonth: "09", Expiryyear: testExpiryYear, ChargeCardURL: server.URL})
+	if got, err := flow.Start(); err == nil || got != CardChargeActionFailed {
+		t.Errorf("CardChargeFlow.Start() = %v, %v, want %v and the decline error", got, err, CardChargeActionFailed)
+	}
+}
+
+var suggestPINChargeResponse = `{"status":"success","message":"AUTH_SUGGESTION","data":{"suggested_auth":"PIN"}}`
+
+var suggestNoAuthInternationalChargeResponse = `{"status":"success","message":"AUTH_SUGGESTION","data":{"suggested_auth":"NOAUTH_INTERNATIONAL"}}`
+
+var redirectCardChargeResponse = `{"status":"success","message":"V-COMP","data":{"id":113259,"txRef":"MXX-ASC-4578","flwRef":"FLW-MOCK-1","amount":300,"chargeResponseCode":"02","chargeResponseMessage":"Success-Pending-otp-validation","authModelUsed":"VBVSECURECODE","currency":"USD","status":"success-pending-validation","authurl":"https://bank.example.com/3ds?ref=FLW-MOCK-1","paymentType":"card"}}`
+
+var declinedCardChargeResponse = `{"status":"error","message":"Card declined by issuer","data":{"code":"FLW_ERR","message":"Card declined by issuer"}}`
@@ -1,6 +1,13 @@
 package ravepay
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
 
 func TestCard_ChargeURL(t *testing.T) {
 	type fields struct {
@@ -71,3 +78,97 @@ func TestCard_ValidateChargeURL(t *testing.T) {
 		})
 	}
 }
+
+func TestCard_BuildChargeRequestPayload_billingAddress(t *testing.T) {
+	card := &Card{CardNo: "4556052704172643"}
+
+	payload := map[string]interface{}{}
+	json.Unmarshal(card.BuildChargeRequestPayload(&ChargeRequest{}), &payload)
+	for _, field := range []string{"billingzip", "billingcity", "billingaddress", "billingstate", "billingcountry"} {
+		if _, ok := payload[field]; ok {
+			t.Errorf("Card.BuildChargeRequestPayload() = %v, want %s omitted when unset", payload, field)
+		}
+	}
+
+	card.SetBillingAddress(BillingAddress{Zip: "07205", City: "Hillside", Address: "470 Mundet PI", State: "NJ", Country: "US"})
+	payload = map[string]interface{}{}
+	json.Unmarshal(card.BuildChargeRequestPayload(&ChargeRequest{SuggestedAuth: SuggestedAuthNoAuthInternational}), &payload)
+	if payload["billingzip"] != "07205" || payload["billingaddress"] != "470 Mundet PI" || payload["billingcountry"] != "US" || payload["suggested_auth"] != "NOAUTH_INTERNATIONAL" {
+		t.Errorf("Card.BuildChargeRequestPayload() = %v", payload)
+	}
+}
+
+func TestCard_validateCharge(t *testing.T) {
+	handler := &testServer{resp: []byte(successfulCardChargeResponse)}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		suggestedAuth string
+		card          *Card
+		wantField     string
+	}{
+		{
+			name: "doesn't require a billing address without avs auth",
+			card: &Card{},
+		},
+		{
+			name:          "requires the billing address for international cards",
+			suggestedAuth: "NOAUTH_INTERNATIONAL",
+			card:          &Card{BillingZip: "07205", BillingCity: "Hillside"},
+			wantField:     "billingaddress",
+		},
+		{
+			name:          "requires the billing address for avs",
+			suggestedAuth: "avs_vbvsecurecode",
+			card:          &Card{},
+			wantField:     "billingzip",
+		},
+		{
+			name:          "accepts a complete billing address",
+			suggestedAuth: "AVS_VBVSECURECODE",
+			card:          &Card{BillingZip: "07205", BillingCity: "Hillside", BillingAddress: "470 Mundet PI", BillingState: "NJ", BillingCountry: "US"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.card.CardNo, tt.card.Cvv, tt.card.Expirymonth, tt.card.Expiryyear = "4556052704172643", "789", "09", testExpiryYear
+			tt.card.ChargeCardURL = server.URL
+			cr := &ChargeRequest{Amount: NewMoney(30000, ""), Email: "tester@flutter.co", TxRef: "MXX-ASC-4578", SuggestedAuth: tt.suggestedAuth}
+			_, err := NewClient(WithKeys(PublicKey, SecretKey)).Charge(cr, tt.card)
+
+			var vErr *ValidationError
+			if tt.wantField == "" && err != nil {
+				t.Errorf("Client.Charge() error = %v, want nil", err)
+			}
+			if tt.wantField != "" && (!errors.As(err, &vErr) || vErr.Field != tt.wantField) {
+				t.Errorf("Client.Charge() error = %v, want a ValidationError on %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestCard_Redacted(t *testing.T) {
+	c := Card{CardNo: "5438898014560229", Cvv: "789", Pin: "3310", Expirymonth: "09", Expiryyear: testExpiryYear}
+
+	b, _ := json.Marshal(c.Redacted())
+	formatted := []string{fmt.Sprint(c), fmt.Sprintf("%+v", &c), fmt.Sprintf("%#v", c), string(b)}
+	for _, got := range formatted {
+		if strings.Contains(got, "5438898014560229") || strings.Contains(got, "789") || strings.Contains(got, "3310") {
+			t.Errorf("formatted card %s has its card details", got)
+		}
+		if !strings.Contains(got, "543889******0229") || !strings.Contains(got, "09") {
+			t.Errorf("formatted card %s doesn't have its masked card number and expiry", got)
+		}
+	}
+	if !strings.HasPrefix(fmt.Sprintf("%#v", c), "ravepay.Card{") {
+		t.Errorf("Card.GoString() = %#v", c)
+	}
+
+	var payload map[string]interface{}
+	json.Unmarshal(c.BuildChargeRequestPayload(&ChargeRequest{}), &payload)
+	if payload["cardno"] != "5438898014560229" || payload["cvv"] != "789" || payload["pin"] != "3310" {
+		t.Errorf("Card.BuildChargeRequestPayload() = %v, want the card details", payload)
+	}
+}
@@ -0,0 +1,159 @@
+package ravepay
+
+import (
+	"strconv"
+	"time"
+)
+
+// CardBrand is the card scheme a card number belongs to
+type CardBrand string
+
+// The card brands detected from card numbers
+const (
+	CardBrandVisa       CardBrand = "visa"
+	CardBrandMastercard CardBrand = "mastercard"
+	CardBrandVerve      CardBrand = "verve"
+	CardBrandAmex       CardBrand = "amex"
+	CardBrandDiscover   CardBrand = "discover"
+	CardBrandJCB        CardBrand = "jcb"
+	CardBrandDinersClub CardBrand = "diners_club"
+	CardBrandUnknown    CardBrand = "unknown"
+)
+
+// cardBrandRange is an IIN range of a card brand and the card numbers lengths it issues
+// prefixes are compared on as many leading digits as the range bounds have
+type cardBrandRange struct {
+	brand    CardBrand
+	low      string
+	high     string
+	lengths  []int
+	cvvLen   int
+	checksum bool
+}
+
+// cardBrandRanges are checked in order, so the narrower ranges come before the ranges they overlap
+// e.g verve's 650002-650027 before discover's 65
+var cardBrandRanges = []cardBrandRange{
+	{brand: CardBrandVerve, low: "506099", high: "506198", lengths: []int{16, 18, 19}, cvvLen: 3},
+	{brand: CardBrandVerve, low: "507865", high: "507964", lengths: []int{16, 18, 19}, cvvLen: 3},
+	{brand: CardBrandVerve, low: "650002", high: "650027", lengths: []int{16, 18, 19}, cvvLen: 3},
+	{brand: CardBrandVisa, low: "4", high: "4", lengths: []int{13, 16, 19}, cvvLen: 3, checksum: true},
+	{brand: CardBrandMastercard, low: "51", high: "55", lengths: []int{16}, cvvLen: 3, checksum: true},
+	{brand: CardBrandMastercard, low: "2221", high: "2720", lengths: []int{16}, cvvLen: 3, checksum: true},
+	{brand: CardBrandAmex, low: "34", high: "34", lengths: []int{15}, cvvLen: 4, checksum: true},
+	{brand: CardBrandAmex, low: "37", high: "37", lengths: []int{15}, cvvLen: 4, checksum: true},
+	{brand: CardBrandDiscover, low: "6011", high: "6011", lengths: []int{16, 17, 18, 19}, cvvLen: 3, checksum: true},
+	{brand: CardBrandDiscover, low: "644", high: "649", lengths: []int{16, 17, 18, 19}, cvvLen: 3, checksum: true},
+	{brand: CardBrandDiscover, low: "65", high: "65", lengths: []int{16, 17, 18, 19}, cvvLen: 3, checksum: true},
+	{brand: CardBrandJCB, low: "3528", high: "3589", lengths: []int{16, 17, 18, 19}, cvvLen: 3, checksum: true},
+	{brand: CardBrandDinersClub, low: "300", high: "305", lengths: []int{14, 15, 16, 17, 18, 19}, cvvLen: 3, checksum: true},
+	{brand: CardBrandDinersClub, low: "36", high: "36", lengths: []int{14, 15, 16, 17, 18, 19}, cvvLen: 3, checksum: true},
+	{brand: CardBrandDinersClub, low: "38", high: "39", lengths: []int{14, 15, 16, 17, 18, 19}, cvvLen: 3, checksum: true},
+}
+
+// unknownCardBrandRange holds the checks for card numbers that match no brand
+var unknownCardBrandRange = cardBrandRange{brand: CardBrandUnknown, lengths: []int{12, 13, 14, 15, 16, 17, 18, 19}, checksum: true}
+
+// DetectCardBrand returns the brand of the card number from its IIN
+// It returns CardBrandUnknown if the number doesn't match any known brand's range
+func DetectCardBrand(cardNo string) CardBrand {
+	return cardBrandRangeFor(cardNo).brand
+}
+
+func cardBrandRangeFor(cardNo string) cardBrandRange {
+	for _, r := range cardBrandRanges {
+		if len(cardNo) < len(r.low) {
+			continue
+		}
+		prefix := cardNo[:len(r.low)]
+		if prefix >= r.low && prefix <= r.high {
+			return r
+		}
+	}
+	return unknownCardBrandRange
+}
+
+// DetectBrand returns the brand of the card detected from its card number
+func (c *Card) DetectBrand() CardBrand {
+	return DetectCardBrand(c.CardNo)
+}
+
+// Validate checks the card number, expiry and cvv before the card is charged
+// The card number must match the lengths of its brand and pass the luhn check, verve cards aren't luhn checked
+// the card must not have expired and the cvv must be as long as its brand's
+// The card's country and currency must also be ones rave accepts
+// It returns ValidationErrors listing every invalid field
+func (c *Card) Validate() error {
+	return c.validate(time.Now())
+}
+
+func (c *Card) validate(now time.Time) error {
+	var errs ValidationErrors
+	r := cardBrandRangeFor(c.CardNo)
+	switch {
+	case c.CardNo == "" || !isDigits(c.CardNo, len(c.CardNo)):
+		errs = append(errs, &ValidationError{Field: "cardno", Message: "must be digits"})
+	case !containsInt(r.lengths, len(c.CardNo)):
+		errs = append(errs, &ValidationError{Field: "cardno", Message: "has an invalid length for a " + string(r.brand) + " card"})
+	case r.checksum && !luhnValid(c.CardNo):
+		errs = append(errs, &ValidationError{Field: "cardno", Message: "fails the luhn check"})
+	}
+
+	month, err := strconv.Atoi(c.Expirymonth)
+	validMonth := err == nil && month >= 1 && month <= 12
+	if !validMonth {
+		errs = append(errs, &ValidationError{Field: "expirymonth", Message: "must be between 01 and 12"})
+	}
+	year, err := strconv.Atoi(c.Expiryyear)
+	if err != nil || (len(c.Expiryyear) != 2 && len(c.Expiryyear) != 4) {
+		errs = append(errs, &ValidationError{Field: "expiryyear", Message: "must be 2 or 4 digits"})
+	} else if validMonth {
+		if len(c.Expiryyear) == 2 {
+			year += 2000
+		}
+		// cards expire at the end of their expiry month
+		if !now.Before(time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC)) {
+			errs = append(errs, &ValidationError{Field: "expiryyear", Message: "the card has expired"})
+		}
+	}
+
+	// cards of unknown brands may have a 3 or 4 digits cvv
+	if r.cvvLen == 0 {
+		if !isDigits(c.Cvv, 3) && !isDigits(c.Cvv, 4) {
+			errs = append(errs, &ValidationError{Field: "cvv", Message: "must be 3 or 4 digits"})
+		}
+	} else if !isDigits(c.Cvv, r.cvvLen) {
+		errs = append(errs, &ValidationError{Field: "cvv", Message: "must be " + strconv.Itoa(r.cvvLen) + " digits for a " + string(r.brand) + " card"})
+	}
+
+	// cards are accepted in any of rave's currencies from any of its countries
+	errs = errs.add("", validateMarket("card", c.Country, c.Currency, nil))
+	return errs.err()
+}
+
+// luhnValid reports whether the digits pass the luhn checksum
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
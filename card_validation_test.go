@@ -0,0 +1,102 @@
+package ravepay
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDetectCardBrand(t *testing.T) {
+	tests := []struct {
+		cardNo string
+		want   CardBrand
+	}{
+		{cardNo: "4111111111111111", want: CardBrandVisa},
+		{cardNo: "5438898014560229", want: CardBrandMastercard},
+		{cardNo: "2223000048400011", want: CardBrandMastercard},
+		{cardNo: "378282246310005", want: CardBrandAmex},
+		{cardNo: "6011111111111117", want: CardBrandDiscover},
+		{cardNo: "6500021234567890", want: CardBrandVerve},
+		{cardNo: "6500281234567890", want: CardBrandDiscover},
+		{cardNo: "5061460410120223210", want: CardBrandVerve},
+		{cardNo: "3530111333300000", want: CardBrandJCB},
+		{cardNo: "30569309025904", want: CardBrandDinersClub},
+		{cardNo: "9999999999999995", want: CardBrandUnknown},
+		{cardNo: "", want: CardBrandUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cardNo, func(t *testing.T) {
+			if got := DetectCardBrand(tt.cardNo); got != tt.want {
+				t.Errorf("DetectCardBrand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCard_validate(t *testing.T) {
+	now := time.Date(2020, time.June, 15, 0, 0, 0, 0, time.UTC)
+	valid := func(modify func(c *Card)) *Card {
+		c := &Card{CardNo: "5438898014560229", Expirymonth: "09", Expiryyear: "21", Cvv: "789"}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name      string
+		card      *Card
+		wantField string
+	}{
+		{name: "accepts a valid card", card: valid(nil)},
+		{name: "accepts a card expiring this month", card: valid(func(c *Card) { c.Expirymonth, c.Expiryyear = "06", "2020" })},
+		{name: "accepts an amex card with a 4 digits cvv", card: valid(func(c *Card) { c.CardNo, c.Cvv = "378282246310005", "1234" })},
+		{name: "accepts a verve card without luhn checking it", card: valid(func(c *Card) { c.CardNo = "5061460410120223210" })},
+		{name: "accepts an unknown brand with a 4 digits cvv", card: valid(func(c *Card) { c.CardNo, c.Cvv = "9999999999999995", "1234" })},
+		{name: "rejects an empty card number", card: valid(func(c *Card) { c.CardNo = "" }), wantField: "cardno"},
+		{name: "rejects a card number with spaces", card: valid(func(c *Card) { c.CardNo = "5438 8980 1456 0229" }), wantField: "cardno"},
+		{name: "rejects a card number of the wrong length", card: valid(func(c *Card) { c.CardNo = "54388980145602" }), wantField: "cardno"},
+		{name: "rejects a card number failing the luhn check", card: valid(func(c *Card) { c.CardNo = "5438898014560228" }), wantField: "cardno"},
+		{name: "rejects an invalid expiry month", card: valid(func(c *Card) { c.Expirymonth = "13" }), wantField: "expirymonth"},
+		{name: "rejects an invalid expiry year", card: valid(func(c *Card) { c.Expiryyear = "202" }), wantField: "expiryyear"},
+		{name: "rejects an expired card", card: valid(func(c *Card) { c.Expirymonth, c.Expiryyear = "05", "20" }), wantField: "expiryyear"},
+		{name: "rejects a 4 digits cvv on a mastercard", card: valid(func(c *Card) { c.Cvv = "7890" }), wantField: "cvv"},
+		{name: "rejects a 3 digits cvv on an amex card", card: valid(func(c *Card) { c.CardNo = "378282246310005" }), wantField: "cvv"},
+		{name: "rejects a missing cvv", card: valid(func(c *Card) { c.Cvv = "" }), wantField: "cvv"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.card.validate(now)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("Card.validate() error = %v", err)
+				}
+				return
+			}
+
+			var vErr *ValidationError
+			if !errors.As(err, &vErr) || vErr.Field != tt.wantField {
+				t.Errorf("Card.validate() error = %v, want ValidationError for %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestLuhnValid(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   bool
+	}{
+		{digits: "4111111111111111", want: true},
+		{digits: "79927398713", want: true},
+		{digits: "79927398710", want: false},
+		{digits: "0", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.digits, func(t *testing.T) {
+			if got := luhnValid(tt.digits); got != tt.want {
+				t.Errorf("luhnValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package ravepay
 
+import (
+	"context"
+	"fmt"
+)
+
 // Chargeable is an abstract representation for chargeable resources
 // like cards and accounts
 type Chargeable interface {
@@ -8,20 +13,51 @@ type Chargeable interface {
 	BuildChargeRequestPayload(*ChargeRequest) []byte
 }
 
+// chargeValidator is implemented by chargeables that check the charge request before it is sent
+type chargeValidator interface {
+	validateCharge(*ChargeRequest) error
+}
+
 // ChargeRequest is a holds information necessary charging a given card
 // it has a charge method defined on it that takes a card and proceeds to charge it with the given information
 type ChargeRequest struct {
-	PBFPubKey         string  `json:"PBFPubKey"`
-	Amount            float64 `json:"amount"`
-	ChargeType        string  `json:"charge_type"`
-	DeviceFingerprint string  `json:"device_fingerprint"`
-	Email             string  `json:"email"`
-	IP                string  `json:"IP"`
-	TxRef             string  `json:"txRef"`
-	PaymentType       string  `json:"payment_type"`
-	PhoneNumber       string  `json:"phonenumber"`
-	RedirectURL       string  `json:"redirect_url"`
-	SuggestedAuth     string  `json:"suggested_auth"`
+	PBFPubKey         string `json:"PBFPubKey"`
+	Amount            Money  `json:"amount"`
+	ChargeType        string `json:"charge_type"`
+	DeviceFingerprint string `json:"device_fingerprint"`
+	Email             string `json:"email"`
+	IP                string `json:"IP"`
+	TxRef             string `json:"txRef"`
+	PaymentType       string `json:"payment_type"`
+	PhoneNumber       string `json:"phonenumber"`
+	RedirectURL       string `json:"redirect_url"`
+	SuggestedAuth     string `json:"suggested_auth"`
+	// PaymentPlan is the id of the payment plan to enrol the customer on, if any
+	PaymentPlan int `json:"payment_plan,omitempty"`
+	// Subaccounts splits the charge between the merchant and the given subaccounts
+	Subaccounts []SubaccountSplit `json:"subaccounts,omitempty"`
+}
+
+// Validate checks the charge request has a txRef, a positive amount, a well formed email and phone number
+// and valid subaccount splits
+func (cr *ChargeRequest) Validate() error {
+	var errs ValidationErrors
+	if cr.TxRef == "" {
+		errs = append(errs, &ValidationError{Field: "txRef", Message: "is required"})
+	}
+	if cr.Amount.Minor <= 0 {
+		errs = append(errs, &ValidationError{Field: "amount", Message: "must be positive"})
+	}
+	if cr.Email == "" {
+		errs = append(errs, &ValidationError{Field: "email", Message: "is required"})
+	} else if !validEmail(cr.Email) {
+		errs = append(errs, &ValidationError{Field: "email", Message: "is malformed"})
+	}
+	if cr.PhoneNumber != "" && !validPhoneNumber(cr.PhoneNumber) {
+		errs = append(errs, &ValidationError{Field: "phonenumber", Message: "is malformed"})
+	}
+	errs = errs.add("subaccounts", validateSplits(cr.Amount, cr.Subaccounts))
+	return errs.err()
 }
 
 // ChargeResponse is a type of rave response to a charge card request
@@ -41,10 +77,12 @@ type chargeResponseData struct {
 	IP                    string      `json:"IP"`
 	Acctvalrespcode       interface{} `json:"acctvalrespcode"`
 	Acctvalrespmsg        interface{} `json:"acctvalrespmsg"`
-	Amount                int         `json:"amount"`
-	Appfee                float64     `json:"appfee"`
+	Amount                Money       `json:"amount"`
+	Appfee                Money       `json:"appfee"`
 	AuthModelUsed         string      `json:"authModelUsed"`
 	Authurl               string      `json:"authurl"`
+	Avsresponsecode       interface{} `json:"avsresponsecode"`
+	Avsresponsemessage    interface{} `json:"avsresponsemessage"`
 	BusinessNumber        string      `json:"business_number,omitempty"`
 	ChargeResponseCode    string      `json:"chargeResponseCode"`
 	ChargeResponseMessage string      `json:"chargeResponseMessage"`
@@ -65,12 +103,12 @@ type chargeResponseData struct {
 	IsLive                        int                  `json:"is_live"`
 	Message                       string               `json:"message"`
 	Merchantbearsfee              int                  `json:"merchantbearsfee"`
-	Merchantfee                   int                  `json:"merchantfee"`
+	Merchantfee                   Money                `json:"merchantfee"`
 	Narration                     string               `json:"narration"`
 	OrderRef                      string               `json:"orderRef"`
 	PaymentID                     string               `json:"paymentId"`
 	PaymentPage                   interface{}          `json:"paymentPage"`
-	PaymentPlan                   interface{}          `json:"paymentPlan"`
+	PaymentPlan                   *PaymentPlan         `json:"paymentPlan"`
 	PaymentType                   string               `json:"paymentType"`
 	RaveRef                       string               `json:"raveRef"`
 	RedirectURL                   string               `json:"redirectUrl"`
@@ -83,6 +121,14 @@ type chargeResponseData struct {
 	UpdatedAt                     string               `json:"updatedAt"`
 	Vbvrespcode                   string               `json:"vbvrespcode"`
 	Vbvrespmessage                string               `json:"vbvrespmessage"`
+	ChargeToken                   ChargeToken          `json:"chargeToken"`
+}
+
+// ChargeToken holds the tokens rave issues for a successfully charged card
+// the embed token charges the card again without the card details, see TokenizedCharge
+type ChargeToken struct {
+	UserToken  string `json:"user_token"`
+	EmbedToken string `json:"embed_token"`
 }
 
 type validateInstructions struct {
@@ -107,13 +153,42 @@ type ChargeValidationResponse struct {
 // Charge makes the request to charge the given card
 // it returns the response from the server
 func (cr *ChargeRequest) Charge(chargeable Chargeable) (*ChargeResponse, error) {
+	return cr.ChargeContext(context.Background(), chargeable)
+}
+
+// ChargeContext is like Charge but the request is bound to the given context
+func (cr *ChargeRequest) ChargeContext(ctx context.Context, chargeable Chargeable) (*ChargeResponse, error) {
+	return defaultClient().ChargeContext(ctx, cr, chargeable)
+}
+
+// Charge makes the request to charge the given chargeable with the charge request
+// it returns the response from the server
+func (c *Client) Charge(cr *ChargeRequest, chargeable Chargeable) (*ChargeResponse, error) {
+	return c.ChargeContext(context.Background(), cr, chargeable)
+}
+
+// ChargeContext is like Charge but the request is bound to the given context
+func (c *Client) ChargeContext(ctx context.Context, cr *ChargeRequest, chargeable Chargeable) (*ChargeResponse, error) {
+	errs := ValidationErrors{}.add("", cr.Validate())
+	errs = errs.add("", validateRequest(chargeable))
+	if v, ok := chargeable.(chargeValidator); ok {
+		errs = errs.add("", v.validateCharge(cr))
+	}
+	if err := errs.err(); err != nil {
+		return &ChargeResponse{}, err
+	}
+
 	if cr.PBFPubKey == "" {
-		cr.PBFPubKey = PublicKey
+		cr.PBFPubKey = c.PublicKey
 	}
-	encryptionKey := getEncryptionKey(SecretKey)
+	encryptionKey := getEncryptionKey(c.SecretKey)
 	reqPayload := chargeable.BuildChargeRequestPayload(cr)
 
-	data := tripleDESEncrypt(reqPayload, []byte(encryptionKey))
+	data, err := tripleDESEncrypt(reqPayload, []byte(encryptionKey))
+	if err != nil {
+		c.log(ctx, LogLevelError, "couldn't encrypt the charge payload", "err", err)
+		return &ChargeResponse{}, fmt.Errorf("ravepay: couldn't encrypt the charge payload: %w", err)
+	}
 
 	payload := struct {
 		PBFPubKey string `json:"PBFPubKey"`
@@ -125,18 +200,62 @@ func (cr *ChargeRequest) Charge(chargeable Chargeable) (*ChargeResponse, error)
 		Alg:       "3DES-24",
 	}
 
-	resp := &ChargeResponse{}
-	err := sendRequestAndParseResponse("POST", chargeable.ChargeURL(), payload, resp)
+	resp, err := c.sendCharge(ctx, c.rebaseURL(chargeable.ChargeURL()), cr.TxRef, payload)
 	resp.ValidateChargeURL = chargeable.ValidateChargeURL()
 
 	return resp, err
 }
 
+// sendCharge sends the charge payload, retrying it by the client's retry policy
+// the charge is only re-sent if rave has no record of a transaction with the txRef
+// if rave has a record of it, the charge response is recovered from the record instead
+func (c *Client) sendCharge(ctx context.Context, url, txRef string, payload interface{}) (*ChargeResponse, error) {
+	resp := &ChargeResponse{}
+	recovered := false
+	resend := func(ctx context.Context) bool {
+		if txRef == "" {
+			return false
+		}
+		lookup, err := c.lookupTransaction(ctx, "", txRef)
+		if err == nil {
+			*resp = chargeResponseFromLookup(lookup)
+			recovered = true
+			return false
+		}
+		return isNotFound(err)
+	}
+
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = ChargeResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", url, payload, resp)
+	}, resend)
+	if recovered {
+		err = nil
+	}
+	return resp, err
+}
+
 // OTPValidation handles the final part to a resource charge using the provided otp
 // returns the server response
 func (cr *ChargeResponse) OTPValidation(otp string) (*ChargeValidationResponse, error) {
+	return cr.OTPValidationContext(context.Background(), otp)
+}
+
+// OTPValidationContext is like OTPValidation but the request is bound to the given context
+func (cr *ChargeResponse) OTPValidationContext(ctx context.Context, otp string) (*ChargeValidationResponse, error) {
+	return defaultClient().OTPValidationContext(ctx, cr, otp)
+}
+
+// OTPValidation handles the final part to the charge in the given charge response using the provided otp
+// returns the server response
+func (c *Client) OTPValidation(cr *ChargeResponse, otp string) (*ChargeValidationResponse, error) {
+	return c.OTPValidationContext(context.Background(), cr, otp)
+}
+
+// OTPValidationContext is like OTPValidation but the request is bound to the given context
+func (c *Client) OTPValidationContext(ctx context.Context, cr *ChargeResponse, otp string) (*ChargeValidationResponse, error) {
 	if cr.PBFPubKey == "" {
-		cr.PBFPubKey = PublicKey
+		cr.PBFPubKey = c.PublicKey
 	}
 
 	payload := struct {
@@ -153,7 +272,7 @@ func (cr *ChargeResponse) OTPValidation(otp string) (*ChargeValidationResponse,
 	}
 
 	resp := &ChargeValidationResponse{}
-	err := sendRequestAndParseResponse("POST", cr.ValidateChargeURL, payload, resp)
+	err := c.sendRequestAndParseResponse(ctx, "POST", c.rebaseURL(cr.ValidateChargeURL), payload, resp)
 
 	return resp, err
 }
@@ -1,8 +1,10 @@
 package ravepay
 
 import (
+	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -13,7 +15,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 
 	type fields struct {
 		PBFPubKey         string
-		Amount            float64
+		Amount            Money
 		ChargeType        string
 		DeviceFingerprint string
 		Email             string
@@ -39,7 +41,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 			name: "returns the charge charge card request response",
 			fields: fields{
 				PBFPubKey:         "FLWPUBK-e634d14d9ded04eaf05d5b63a0a06d2f-X",
-				Amount:            300,
+				Amount:            NewMoney(30000, ""),
 				Email:             "tester@flutter.co",
 				IP:                "103.238.105.185",
 				TxRef:             "'MXX-ASC-4578",
@@ -55,7 +57,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 					Country:               "NG",
 					Cvv:                   "789",
 					Expirymonth:           "09",
-					Expiryyear:            "19",
+					Expiryyear:            testExpiryYear,
 					Pin:                   "3310",
 				},
 			},
@@ -65,7 +67,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 				Data: chargeResponseData{
 					AccountID:                     134,
 					IP:                            "::ffff:127.0.0.1",
-					Amount:                        300,
+					Amount:                        NewMoney(30000, ""),
 					AuthModelUsed:                 "PIN",
 					Authurl:                       "http://flw-pms-dev.eu-west-1.elasticbeanstalk.com/mockvbvpage?ref=FLW-MOCK-0cd9a725cf2ad31303299840f5a0896a&code=00&message=Approved. Successful&receiptno=RN1521335722125",
 					Customercandosubsequentnoauth: true,
@@ -103,7 +105,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 			name: "returns response if charge card request has auth suggestion",
 			fields: fields{
 				PBFPubKey:         "FLWPUBK-e634d14d9ded04eaf05d5b63a0a06d2f-X",
-				Amount:            300,
+				Amount:            NewMoney(30000, ""),
 				Email:             "tester@flutter.co",
 				IP:                "103.238.105.185",
 				TxRef:             "'MXX-ASC-4578",
@@ -118,7 +120,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 					Country:               "NG",
 					Cvv:                   "789",
 					Expirymonth:           "09",
-					Expiryyear:            "19",
+					Expiryyear:            testExpiryYear,
 				},
 			},
 			want: &ChargeResponse{
@@ -132,9 +134,17 @@ func TestChargeRequest_Charge(t *testing.T) {
 		},
 		{
 			name: "returns response if charge card request fails",
+			fields: fields{
+				Amount: NewMoney(30000, ""),
+				Email:  "tester@flutter.co",
+				TxRef:  "MXX-ASC-4578",
+			},
 			args: args{
 				chargeable: &Card{
-					CardNo:                "5",
+					CardNo:                "5438898014560229",
+					Cvv:                   "789",
+					Expirymonth:           "09",
+					Expiryyear:            testExpiryYear,
 					ChargeCardURL:         server.URL,
 					ValidateCardChargeURL: server.URL,
 				},
@@ -149,10 +159,15 @@ func TestChargeRequest_Charge(t *testing.T) {
 				ValidateChargeURL: server.URL,
 			},
 			serverResp: failedCardChargeResponse,
-			wantErr:    false,
+			wantErr:    true,
 		},
 		{
 			name: "returns response if charge account request succeeds",
+			fields: fields{
+				Amount: NewMoney(30000, ""),
+				Email:  "tester@flutter.co",
+				TxRef:  "MXX-ASC-4578",
+			},
 			args: args{
 				chargeable: &Account{
 					AccountBank:              "044",
@@ -168,7 +183,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 				Data: chargeResponseData{
 					AccountID:                     134,
 					IP:                            "::ffff:127.0.0.1",
-					Amount:                        300,
+					Amount:                        NewMoney(30000, ""),
 					AuthModelUsed:                 "AUTH",
 					Authurl:                       "NO-URL",
 					Customercandosubsequentnoauth: false,
@@ -217,10 +232,15 @@ func TestChargeRequest_Charge(t *testing.T) {
 		},
 		{
 			name: "returns response if charge account request fails",
+			fields: fields{
+				Amount: NewMoney(30000, ""),
+				Email:  "tester@flutter.co",
+				TxRef:  "MXX-ASC-4578",
+			},
 			args: args{
 				chargeable: &Account{
 					AccountBank:              "044",
-					AccountNumber:            "",
+					AccountNumber:            "0690000032",
 					Country:                  "NG",
 					ChargeAccountURL:         server.URL,
 					ValidateAccountChargeURL: server.URL,
@@ -233,13 +253,13 @@ func TestChargeRequest_Charge(t *testing.T) {
 				ValidateChargeURL: server.URL,
 			},
 			serverResp: failedAccountChargeResponse,
-			wantErr:    false,
+			wantErr:    true,
 		},
 		{
 			name: "returns response if charge mpesa request succeeds",
 			fields: fields{
 				PBFPubKey:         "FLWPUBK-e634d14d9ded04eaf05d5b63a0a06d2f-X",
-				Amount:            300,
+				Amount:            NewMoney(30000, ""),
 				Email:             "tester@flutter.co",
 				IP:                "103.238.105.185",
 				TxRef:             "'MXX-ASC-4578",
@@ -261,7 +281,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 				Status:  "success",
 				Data: chargeResponseData{
 					AccountID:         134,
-					Amount:            300,
+					Amount:            NewMoney(30000, ""),
 					AuthModelUsed:     "VBVSECURECODE",
 					Authurl:           "N/A",
 					BusinessNumber:    "637747",
@@ -294,7 +314,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 			name: "returns response if charge mobile money gh request succeeds",
 			fields: fields{
 				PBFPubKey:         "FLWPUBK-e634d14d9ded04eaf05d5b63a0a06d2f-X",
-				Amount:            300,
+				Amount:            NewMoney(30000, ""),
 				Email:             "tester@flutter.co",
 				IP:                "103.238.105.185",
 				TxRef:             "'MXX-ASC-4578",
@@ -315,8 +335,8 @@ func TestChargeRequest_Charge(t *testing.T) {
 				Status:  "success",
 				Data: chargeResponseData{
 					AccountID:             48,
-					Amount:                3,
-					Appfee:                0.037500000000000006,
+					Amount:                NewMoney(300, ""),
+					Appfee:                NewMoney(4, ""),
 					AuthModelUsed:         "MOBILEMONEY",
 					Authurl:               "NO-URL",
 					ChargeType:            "normal",
@@ -360,7 +380,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 			name: "returns response for charge ussd request",
 			fields: fields{
 				PBFPubKey:         "FLWPUBK-e634d14d9ded04eaf05d5b63a0a06d2f-X",
-				Amount:            300,
+				Amount:            NewMoney(30000, ""),
 				Email:             "tester@flutter.co",
 				IP:                "103.238.105.185",
 				TxRef:             "'MXX-ASC-4578",
@@ -382,7 +402,7 @@ func TestChargeRequest_Charge(t *testing.T) {
 				Message: "V-COMP",
 				Status:  "success",
 				Data: chargeResponseData{
-					Amount: 300,
+					Amount: NewMoney(30000, ""),
 					FlwRef: "FLWMM1522085245161",
 					Status: "pending",
 				},
@@ -420,14 +440,32 @@ func TestChargeRequest_Charge(t *testing.T) {
 	}
 
 	// it sets the PBFPubKey if empty
-	chargeRequest := ChargeRequest{}
-	chargeRequest.Charge(&Card{ChargeCardURL: server.URL})
+	chargeRequest := ChargeRequest{Amount: NewMoney(30000, ""), Email: "tester@flutter.co", TxRef: "MXX-ASC-4578"}
+	chargeRequest.Charge(&Card{CardNo: "5438898014560229", Cvv: "789", Expirymonth: "09", Expiryyear: testExpiryYear, ChargeCardURL: server.URL})
 
 	if chargeRequest.PBFPubKey != PublicKey {
 		t.Errorf("chargeRequest.PBFPubKey = %s, want %s", chargeRequest.PBFPubKey, PublicKey)
 	}
 }
 
+func TestClient_Charge_encryptionFails(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(successfulCardChargeResponse))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithKeys(PublicKey, "FLWSECK-12345"), WithBaseURL(server.URL))
+	_, err := c.Charge(&ChargeRequest{Amount: NewMoney(30000, ""), Email: "tester@flutter.co", TxRef: "MXX-ASC-4578"}, &Card{CardNo: "5438898014560229", Cvv: "789", Expirymonth: "09", Expiryyear: testExpiryYear, ChargeCardURL: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "encrypt") {
+		t.Errorf("Client.Charge() error = %v, want the encryption error", err)
+	}
+	if requests != 0 {
+		t.Errorf("Client.Charge() sent %d requests with a payload it couldn't encrypt", requests)
+	}
+}
+
 func TestChargeResponse_OTPValidation(t *testing.T) {
 	handler := &testServer{}
 	server := httptest.NewServer(handler)
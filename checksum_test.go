@@ -17,7 +17,7 @@ func TestCalculateChecksum(t *testing.T) {
 			name: "Calculates the correct checksum val",
 			args: args{
 				payload: Payment{
-					Amount:            20,
+					Amount:            NewMoney(2000, ""),
 					PaymentMethod:     "both",
 					CustomDescription: "Pay Internet",
 					CustomLogo:        "http://localhost/payporte-3/skin/frontend/ultimo/shoppy/custom/images/logo.svg",
@@ -0,0 +1,131 @@
+package ravepay
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultHTTPTimeout bounds requests made without a context deadline
+// so a hung rave endpoint can't block callers forever
+const defaultHTTPTimeout = 60 * time.Second
+
+var defaultHTTPClient = &http.Client{Timeout: defaultHTTPTimeout}
+
+// Client is a rave api client scoped to a single set of keys and mode of operation
+// It exposes every rave operation as a method so several merchants (or modes) can be used in one process
+// The package level functions are thin wrappers around a default client built from the package config
+type Client struct {
+	// PublicKey is the rave public key used by this client
+	PublicKey string
+	// SecretKey is the rave secret key used by this client
+	SecretKey string
+
+	mode        string
+	baseURL     string
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
+	logger      Logger
+}
+
+// ClientOption configures a Client on creation
+type ClientOption func(*Client)
+
+// WithKeys sets the rave public and secret keys used by the client
+func WithKeys(publicKey, secretKey string) ClientOption {
+	return func(c *Client) {
+		c.PublicKey = publicKey
+		c.SecretKey = secretKey
+	}
+}
+
+// WithMode sets the client's mode of operation, live or test
+// It also points the client at the matching rave api servers
+func WithMode(mode string) ClientOption {
+	return func(c *Client) {
+		if mode == "live" {
+			c.mode = "live"
+			c.baseURL = liveModeBaseURL
+			return
+		}
+		c.mode = "test"
+		c.baseURL = testModeBaseURL
+	}
+}
+
+// WithBaseURL overrides the rave api base url used by the client
+// It should be applied after WithMode since WithMode resets the base url
+func WithBaseURL(url string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithHTTPClient sets the http client used for making requests to rave
+// By default a client with a 60 seconds timeout is used
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithLogger sets the logger the client logs its errors and, at debug level, its requests and responses to
+// By default clients log to the package logger, see SetLogger
+func WithLogger(l Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// NewClient returns a new rave client configured with the given options
+// Without options, it returns a test mode client with no keys
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		mode:       "test",
+		baseURL:    testModeBaseURL,
+		httpClient: defaultHTTPClient,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = defaultHTTPClient
+	}
+
+	return c
+}
+
+// defaultClient returns a client built from the package level config
+// It is what the package level functions delegate to
+func defaultClient() *Client {
+	return &Client{
+		PublicKey:  PublicKey,
+		SecretKey:  SecretKey,
+		mode:       currentMode,
+		baseURL:    baseURL,
+		httpClient: defaultHTTPClient,
+	}
+}
+
+// Mode returns the client's mode of operation, live or test
+func (c *Client) Mode() string {
+	return c.mode
+}
+
+func (c *Client) buildURL(path string) string {
+	return fmt.Sprintf("%s%s", c.baseURL, path)
+}
+
+// rebaseURL points urls on rave's live or test servers to the client's base url
+// urls on any other host are considered custom endpoints and returned untouched
+func (c *Client) rebaseURL(url string) string {
+	for _, base := range []string{testModeBaseURL, liveModeBaseURL} {
+		if strings.HasPrefix(url, base) {
+			return c.baseURL + strings.TrimPrefix(url, base)
+		}
+	}
+	return url
+}
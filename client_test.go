@@ -0,0 +1,160 @@
+package ravepay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	hc := &http.Client{}
+
+	tests := []struct {
+		name        string
+		opts        []ClientOption
+		wantMode    string
+		wantBaseURL string
+		wantPubKey  string
+		wantSecKey  string
+		wantHTTP    *http.Client
+	}{
+		{
+			name:        "defaults to test mode without keys",
+			wantMode:    "test",
+			wantBaseURL: testModeBaseURL,
+			wantHTTP:    defaultHTTPClient,
+		},
+		{
+			name:        "sets the keys and live mode",
+			opts:        []ClientOption{WithKeys("pub-key", "sec-key"), WithMode("live")},
+			wantMode:    "live",
+			wantBaseURL: liveModeBaseURL,
+			wantPubKey:  "pub-key",
+			wantSecKey:  "sec-key",
+			wantHTTP:    defaultHTTPClient,
+		},
+		{
+			name:        "overrides the base url and http client",
+			opts:        []ClientOption{WithMode("live"), WithBaseURL("http://rave.local/"), WithHTTPClient(hc)},
+			wantMode:    "live",
+			wantBaseURL: "http://rave.local",
+			wantHTTP:    hc,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(tt.opts...)
+			if c.Mode() != tt.wantMode {
+				t.Errorf("Client.Mode() = %s, want %s", c.Mode(), tt.wantMode)
+			}
+			if c.baseURL != tt.wantBaseURL {
+				t.Errorf("Client.baseURL = %s, want %s", c.baseURL, tt.wantBaseURL)
+			}
+			if c.PublicKey != tt.wantPubKey || c.SecretKey != tt.wantSecKey {
+				t.Errorf("Client keys = %s, %s, want %s, %s", c.PublicKey, c.SecretKey, tt.wantPubKey, tt.wantSecKey)
+			}
+			if c.httpClient != tt.wantHTTP {
+				t.Errorf("Client.httpClient = %v, want %v", c.httpClient, tt.wantHTTP)
+			}
+		})
+	}
+}
+
+func TestClient_rebaseURL(t *testing.T) {
+	c := NewClient(WithBaseURL("http://rave.local"))
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "rebases urls on the test servers",
+			url:  testModeBaseURL + defaultChargeURL,
+			want: "http://rave.local" + defaultChargeURL,
+		},
+		{
+			name: "rebases urls on the live servers",
+			url:  liveModeBaseURL + getFeeURL,
+			want: "http://rave.local" + getFeeURL,
+		},
+		{
+			name: "leaves custom urls untouched",
+			url:  "https://charge.card.url",
+			want: "https://charge.card.url",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.rebaseURL(tt.url); got != tt.want {
+				t.Errorf("Client.rebaseURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_usesItsOwnKeys(t *testing.T) {
+	var gotPath string
+	var gotPayload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotPayload)
+		w.Write([]byte(successfulRefundTxnResponse))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithKeys("merchant-pub-key", "merchant-sec-key"), WithBaseURL(server.URL))
+
+	resp, err := c.Refund("some-txn-ref")
+	if err != nil {
+		t.Fatalf("Client.Refund() error = %v", err)
+	}
+	if resp.Status != "success" {
+		t.Errorf("Client.Refund() status = %s, want success", resp.Status)
+	}
+	if gotPath != refundTxnURL {
+		t.Errorf("request path = %s, want %s", gotPath, refundTxnURL)
+	}
+	if gotPayload["SECKEY"] != "merchant-sec-key" || gotPayload["ref"] != "some-txn-ref" {
+		t.Errorf("request payload = %v, want the client's secret key and ref", gotPayload)
+	}
+
+	tvc := c.NewTxnVerificationChecklist(NewMoney(30000, ""), "some-flw-ref", "NGN")
+	if tvc.SECKEY != "merchant-sec-key" || tvc.VerificationURL != server.URL+txnVerificationURL {
+		t.Errorf("Client.NewTxnVerificationChecklist() = %+v, want client's key and url", tvc)
+	}
+}
+
+func TestClient_ListBanksContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := NewClient(WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.ListBanksContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Client.ListBanksContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	_, errs := (&TxnVerificationChecklist{FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", Amount: NewMoney(30000, ""), TransactionCurrency: "NGN", VerificationURL: server.URL}).VerifyTransactionContext(ctx)
+	if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("TxnVerificationChecklist.VerifyTransactionContext() errs = %v, want %v", errs, context.Canceled)
+	}
+}
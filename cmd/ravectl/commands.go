@@ -0,0 +1,475 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	ravepay "github.com/0sc/rave"
+)
+
+// parseAmount parses the decimal amount flag, an unset amount is zero
+func parseAmount(fs *flag.FlagSet, a *app, amount, currency string) (ravepay.Money, error) {
+	if amount == "" {
+		return ravepay.Money{}, nil
+	}
+	m, err := ravepay.ParseMoney(amount, currency)
+	if err != nil {
+		return ravepay.Money{}, a.usageError(fs, fmt.Sprintf("invalid --amount %q", amount))
+	}
+	return m, nil
+}
+
+// checklistFlags are the flags of the verification commands
+type checklistFlags struct {
+	flwRef, txRef, amount, currency, by, fraudStatus, paymentEntity *string
+	exact, chargedAmount                                            *bool
+}
+
+func addChecklistFlags(fs *flag.FlagSet) checklistFlags {
+	return checklistFlags{
+		flwRef:        fs.String("flwref", "", "rave's reference of the transaction"),
+		txRef:         fs.String("txref", "", "the merchant's reference of the transaction"),
+		amount:        fs.String("amount", "", "the amount the transaction is verified against e.g 3000.50, required"),
+		currency:      fs.String("currency", "", "the currency the transaction is verified against e.g NGN, required"),
+		by:            fs.String("by", "", "the reference the transaction is verified by: flwref or txref, defaults to the flwref if it's given"),
+		exact:         fs.Bool("exact", false, "require exactly the amount instead of at least it"),
+		chargedAmount: fs.Bool("charged-amount", false, "compare the amount charged the customer, fees included"),
+		fraudStatus:   fs.String("fraud-status", "", "comma separated fraud statuses allowed e.g ok"),
+		paymentEntity: fs.String("payment-entity", "", "the payment entity required e.g card or account"),
+	}
+}
+
+func (f checklistFlags) checklist(fs *flag.FlagSet, a *app) (*ravepay.TxnVerificationChecklist, error) {
+	if *f.flwRef == "" && *f.txRef == "" {
+		return nil, a.usageError(fs, "--flwref or --txref is required")
+	}
+	if *f.amount == "" || *f.currency == "" {
+		return nil, a.usageError(fs, "--amount and --currency are required")
+	}
+	amount, err := parseAmount(fs, a, *f.amount, *f.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := ravepay.VerificationPolicy{ExactAmount: *f.exact, ChargedAmount: *f.chargedAmount, PaymentEntity: *f.paymentEntity}
+	switch *f.by {
+	case "":
+	case "flwref":
+		policy.By = ravepay.VerifyByFlwRef
+	case "txref":
+		policy.By = ravepay.VerifyByTxRef
+	default:
+		return nil, a.usageError(fs, fmt.Sprintf("invalid --by %q, it's flwref or txref", *f.by))
+	}
+	for _, status := range strings.Split(*f.fraudStatus, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			policy.FraudStatuses = append(policy.FraudStatuses, status)
+		}
+	}
+
+	return &ravepay.TxnVerificationChecklist{FlwRef: *f.flwRef, TxRef: *f.txRef, Amount: amount, TransactionCurrency: *f.currency, Policy: policy}, nil
+}
+
+// verification is the json output of the verification commands
+type verification struct {
+	Response interface{}                 `json:"response"`
+	Verified bool                        `json:"verified"`
+	Checks   []ravepay.VerificationCheck `json:"checks"`
+	Failures []string                    `json:"failures,omitempty"`
+}
+
+// printVerification prints the verified transaction and the checks that failed
+// it fails the command if any check failed
+func (a *app) printVerification(resp interface{}, rows []row, report *ravepay.VerificationReport) error {
+	v := verification{Response: resp, Verified: report.Passed(), Checks: report.Checks}
+	for _, err := range report.Errors() {
+		v.Failures = append(v.Failures, err.Error())
+	}
+
+	rows = append(rows, row{"verified", v.Verified})
+	for _, f := range v.Failures {
+		rows = append(rows, row{"failed", f})
+	}
+	if err := a.print(v, rows); err != nil {
+		return err
+	}
+	if !v.Verified {
+		return errSilent
+	}
+	return nil
+}
+
+func verifyCmd(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("verify", "")
+	cf := addChecklistFlags(fs)
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	tvc, err := cf.checklist(fs, a)
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	resp, report, err := c.VerifyTransactionReportContext(ctx, tvc)
+	if err != nil {
+		return a.done(err)
+	}
+	d := resp.Data
+	return a.printVerification(resp, []row{
+		{"flw_ref", d.FlwRef},
+		{"tx_ref", d.TxRef},
+		{"amount", d.Amount},
+		{"currency", d.TransactionCurrency},
+		{"status", d.Status},
+		{"charge_response", strings.TrimSpace(d.FlwMeta.ChargeResponse + " " + d.FlwMeta.ChargeResponseMessage)},
+	}, report)
+}
+
+func xrequeryCmd(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("xrequery", "")
+	cf := addChecklistFlags(fs)
+	lastAttempt := fs.Bool("last-attempt", false, "only the last attempt of the transaction")
+	onlySuccessful := fs.Bool("only-successful", false, "only successful attempts of the transaction")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	tvc, err := cf.checklist(fs, a)
+	if err != nil {
+		return err
+	}
+	tvc.Flwref, tvc.Txref = tvc.FlwRef, tvc.TxRef
+	if *lastAttempt {
+		tvc.LastAttempt = "1"
+	}
+	if *onlySuccessful {
+		tvc.OnlySuccessful = "1"
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	resp, report, err := c.VerifyXRequeryTransactionReportContext(ctx, tvc)
+	if err != nil {
+		return a.done(err)
+	}
+	d := resp.Data
+	return a.printVerification(resp, []row{
+		{"flw_ref", d.Flwref},
+		{"tx_ref", d.Txref},
+		{"amount", d.Amount},
+		{"currency", d.Currency},
+		{"status", d.Status},
+		{"charge_response", strings.TrimSpace(d.Chargecode + " " + d.Chargemessage)},
+		{"payment_type", d.Paymenttype},
+		{"created", d.Created},
+	}, report)
+}
+
+func refundCmd(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("refund", "")
+	flwRef := fs.String("flwref", "", "rave's reference of the transaction")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *flwRef == "" {
+		return a.usageError(fs, "--flwref is required")
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.RefundContext(ctx, *flwRef)
+	if err != nil {
+		return a.done(err)
+	}
+	return a.print(resp, []row{
+		{"flw_ref", resp.Data.FlwRef},
+		{"amount_refunded", resp.Data.AmountRefunded},
+		{"status", resp.Data.Status},
+		{"message", resp.Message},
+	})
+}
+
+func preauthCmd(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("preauth", "capture|void|refund")
+	flwRef := fs.String("flwref", "", "rave's reference of the preauthorized charge")
+	amount := fs.String("amount", "", "the amount to capture, the full amount is captured if it's not set")
+	currency := fs.String("currency", "NGN", "the currency of the preauthorized charge")
+	action := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if action != "capture" && action != "void" && action != "refund" {
+		return a.usageError(fs, "capture, void or refund is required")
+	}
+	if *flwRef == "" {
+		return a.usageError(fs, "--flwref is required")
+	}
+	capture, err := parseAmount(fs, a, *amount, *currency)
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	if action == "capture" {
+		resp, err := c.CapturePreAuthPaymentAmountContext(ctx, *flwRef, capture)
+		if err != nil {
+			return a.done(err)
+		}
+		return a.print(resp, chargeRows(resp))
+	}
+
+	var resp *ravepay.PreAuthResponse
+	if action == "void" {
+		resp, err = c.VoidPreAuthPaymentContext(ctx, *flwRef)
+	} else {
+		resp, err = c.RefundPreAuthPaymentContext(ctx, *flwRef)
+	}
+	if err != nil {
+		return a.done(err)
+	}
+	d := resp.Data.Data
+	return a.print(resp, []row{
+		{"flw_ref", d.Transactionreference},
+		{"status", resp.Data.Status},
+		{"response", strings.TrimSpace(d.Responsecode + " " + d.Responsemessage)},
+		{"message", resp.Message},
+	})
+}
+
+func banksCmd(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("banks", "")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	banks, err := c.ListBanksContext(ctx)
+	if err != nil {
+		return a.done(err)
+	}
+	rows := []row{{"CODE", "NAME"}}
+	for _, b := range banks {
+		rows = append(rows, row{b.Code, b.Name})
+	}
+	return a.print(banks, rows)
+}
+
+func feeCmd(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("fee", "")
+	amount := fs.String("amount", "", "the amount to get the fee of e.g 3000.50")
+	currency := fs.String("currency", "NGN", "the currency of the amount")
+	ptype := fs.String("ptype", "", "the payment type, 2 for account payments")
+	card6 := fs.String("card6", "", "the first 6 digits of the card for card payments")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	m, err := parseAmount(fs, a, *amount, *currency)
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.GetFeeContext(ctx, &ravepay.GetFeeRequest{Amount: m, Currency: *currency, PType: *ptype, Card6: *card6})
+	if err != nil {
+		return a.done(err)
+	}
+	return a.print(resp, []row{
+		{"charge_amount", resp.Data.ChargeAmount},
+		{"fee", resp.Data.Fee},
+		{"merchant_fee", resp.Data.Merchantfee},
+		{"rave_fee", resp.Data.Ravefee},
+	})
+}
+
+func forexCmd(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("forex", "")
+	from := fs.String("from", "", "the origin currency e.g USD")
+	to := fs.String("to", "", "the destination currency e.g NGN")
+	amount := fs.String("amount", "", "an amount of the origin currency to convert")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	params := &ravepay.ForexParams{OriginCurrency: *from, DestinationCurrency: *to}
+	if *amount != "" {
+		m, err := parseAmount(fs, a, *amount, *from)
+		if err != nil {
+			return err
+		}
+		params.Amount = &m
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.ForexRateContext(ctx, params)
+	if err != nil {
+		return a.done(err)
+	}
+	rows := []row{
+		{"rate", resp.Data.Rate},
+		{"origin_currency", resp.Data.Origincurrency},
+		{"destination_currency", resp.Data.Destinationcurrency},
+	}
+	if params.Amount != nil {
+		rows = append(rows, row{"original_amount", resp.Data.OriginalAmount}, row{"converted_amount", resp.Data.ConvertedAmount})
+	}
+	return a.print(resp, rows)
+}
+
+func checksumCmd(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("checksum", "")
+	payload := fs.String("payload", "", "the payment as json, or @file to read it from a file")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *payload == "" {
+		return a.usageError(fs, "--payload is required")
+	}
+
+	b := []byte(*payload)
+	if strings.HasPrefix(*payload, "@") {
+		var err error
+		if b, err = os.ReadFile(strings.TrimPrefix(*payload, "@")); err != nil {
+			return err
+		}
+	}
+	var p ravepay.Payment
+	if err := json.Unmarshal(b, &p); err != nil {
+		return fmt.Errorf("invalid payment: %w", err)
+	}
+	cfg, err := a.config()
+	if err != nil {
+		return err
+	}
+
+	checksum := ravepay.CalculateChecksum(p, []byte(cfg.PublicKey), []byte(cfg.SecretKey))
+	return a.print(map[string]string{"checksum": checksum}, []row{{"checksum", checksum}})
+}
+
+// chargeResult is the json output of the charge command
+type chargeResult struct {
+	Next       string                            `json:"next,omitempty"`
+	Charge     *ravepay.ChargeResponse           `json:"charge"`
+	Validation *ravepay.ChargeValidationResponse `json:"validation,omitempty"`
+}
+
+func chargeCmd(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("charge", "")
+	txRef := fs.String("txref", "", "the merchant's reference of the charge")
+	amount := fs.String("amount", "", "the amount to charge e.g 3000.50")
+	currency := fs.String("currency", "NGN", "the currency of the amount")
+	country := fs.String("country", "NG", "the country of the card or account")
+	email := fs.String("email", "", "the customer's email")
+	phone := fs.String("phone", "", "the customer's phone number")
+	cardNo := fs.String("cardno", "", "the card number, to charge a card")
+	cvv := fs.String("cvv", "", "the card's cvv")
+	expiry := fs.String("expiry", "", "the card's expiry as MM/YY")
+	pin := fs.String("pin", "", "the card's pin, if rave asks for it")
+	var billing ravepay.BillingAddress
+	fs.StringVar(&billing.Address, "billing-address", "", "the card holder's billing address, if rave asks for it")
+	fs.StringVar(&billing.City, "billing-city", "", "the billing address's city")
+	fs.StringVar(&billing.State, "billing-state", "", "the billing address's state")
+	fs.StringVar(&billing.Country, "billing-country", "", "the billing address's country")
+	fs.StringVar(&billing.Zip, "billing-zip", "", "the billing address's zip code")
+	accountBank := fs.String("account-bank", "", "the bank code of the account, to charge a bank account")
+	accountNumber := fs.String("account-number", "", "the account number")
+	otp := fs.String("otp", "", "the otp to validate the charge with, if rave asks for it")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if (*cardNo == "") == (*accountNumber == "") {
+		return a.usageError(fs, "--cardno or --account-number is required")
+	}
+	m, err := parseAmount(fs, a, *amount, *currency)
+	if err != nil {
+		return err
+	}
+	cr := &ravepay.ChargeRequest{TxRef: *txRef, Amount: m, Email: *email, PhoneNumber: *phone}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	if *accountNumber != "" {
+		account := &ravepay.Account{AccountBank: *accountBank, AccountNumber: *accountNumber, Currency: *currency, Country: *country}
+		resp, err := c.ChargeContext(ctx, cr, account)
+		if err != nil {
+			return a.done(err)
+		}
+		result := chargeResult{Charge: resp}
+		if *otp != "" {
+			if result.Validation, err = c.OTPValidationContext(ctx, resp, *otp); err != nil {
+				return a.done(err)
+			}
+		}
+		return a.print(result, append(chargeRows(resp), validationRows(result.Validation)...))
+	}
+
+	month, year, _ := strings.Cut(*expiry, "/")
+	card := &ravepay.Card{CardNo: *cardNo, Cvv: *cvv, Expirymonth: month, Expiryyear: year, Currency: *currency, Country: *country}
+	f := c.NewCardChargeFlow(cr, card)
+	action, err := f.StartContext(ctx)
+	if action == ravepay.CardChargeActionPIN && *pin != "" {
+		action, err = f.SupplyPINContext(ctx, *pin)
+	}
+	if action == ravepay.CardChargeActionBillingAddress && billing.Address != "" {
+		action, err = f.SupplyBillingAddressContext(ctx, billing)
+	}
+	if action == ravepay.CardChargeActionOTP && *otp != "" {
+		action, err = f.SubmitOTPContext(ctx, *otp)
+	}
+	if err != nil {
+		return a.done(err)
+	}
+
+	result := chargeResult{Next: string(action), Charge: f.Response(), Validation: f.ValidationResponse()}
+	rows := append(chargeRows(f.Response()), validationRows(f.ValidationResponse())...)
+	rows = append(rows, row{"next", action})
+	if action == ravepay.CardChargeActionRedirect {
+		rows = append(rows, row{"auth_url", f.AuthURL()})
+	}
+	return a.print(result, rows)
+}
+
+func chargeRows(resp *ravepay.ChargeResponse) []row {
+	d := resp.Data
+	return []row{
+		{"flw_ref", d.FlwRef},
+		{"tx_ref", d.TxRef},
+		{"amount", d.Amount},
+		{"status", d.Status},
+		{"charge_response", strings.TrimSpace(d.ChargeResponseCode + " " + d.ChargeResponseMessage)},
+		{"auth_model", d.AuthModelUsed},
+	}
+}
+
+func validationRows(resp *ravepay.ChargeValidationResponse) []row {
+	if resp == nil {
+		return nil
+	}
+	return []row{{"validation", strings.TrimSpace(resp.Status + " " + resp.Message)}}
+}
@@ -0,0 +1,400 @@
+// Command ravectl verifies, refunds and inspects rave transactions from the command line
+//
+// Usage:
+//
+//	ravectl <command> [flags]
+//
+// The commands are verify, xrequery, refund, preauth, banks, fee, forex, checksum and charge
+// run ravectl <command> -h for a command's flags
+//
+// Keys and mode are read from the RAVE_PUBLIC_KEY, RAVE_SECRET_KEY and RAVE_MODE env vars like the rave package,
+// with the default profile of the profile file filling in what they don't set
+// The profile file is $RAVECTL_CONFIG or ravectl/profiles in the user's config dir e.g ~/.config/ravectl/profiles
+//
+//	[default]
+//	public_key = FLWPUBK-...
+//	secret_key = FLWSECK-...
+//	mode = test
+//
+// A profile picked with --profile takes precedence over the env vars
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	ravepay "github.com/0sc/rave"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// command is a ravectl subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = []command{
+	{"verify", "verify a transaction by --flwref or --txref", verifyCmd},
+	{"xrequery", "verify a transaction with xrequery", xrequeryCmd},
+	{"refund", "refund a transaction", refundCmd},
+	{"preauth", "capture, void or refund a preauthorized payment", preauthCmd},
+	{"banks", "list the banks", banksCmd},
+	{"fee", "get the fee of an amount", feeCmd},
+	{"forex", "get the exchange rate between currencies", forexCmd},
+	{"checksum", "calculate the checksum of an inline js payment", checksumCmd},
+	{"charge", "charge a card or bank account", chargeCmd},
+}
+
+// errUsage is returned for invalid arguments, the usage is printed by the flag set
+var errUsage = errors.New("invalid usage")
+
+// errSilent is returned by commands that already reported why they failed
+var errSilent = errors.New("failed")
+
+// run runs the command in args and returns the process exit code
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		a := &app{stdout: stdout, stderr: stderr, getenv: getenv}
+		err := cmd.run(ctx, a, args[1:])
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		case !errors.Is(err, errSilent):
+			fmt.Fprintf(stderr, "ravectl %s: %v\n", cmd.name, err)
+		}
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "ravectl: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: ravectl <command> [flags]\n\nCommands:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.summary)
+	}
+	tw.Flush()
+	fmt.Fprintln(w, "\nRun ravectl <command> -h for a command's flags")
+}
+
+// app holds the flags every command takes and the output of the command
+type app struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+
+	output  string
+	profile string
+	baseURL string
+	dryRun  bool
+	verbose bool
+
+	dry *dryRunTransport
+}
+
+// flagSet returns a flag set for the command with the flags every command takes
+func (a *app) flagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet("ravectl "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.output, "output", "table", "output format, table or json")
+	fs.StringVar(&a.output, "o", "table", "shorthand for --output")
+	fs.StringVar(&a.profile, "profile", "", "profile to read the keys and mode from")
+	fs.StringVar(&a.baseURL, "base-url", "", "rave api base url, overrides the mode's")
+	fs.BoolVar(&a.dryRun, "dry-run", false, "print the request that would be sent, with charges decrypted, instead of sending it")
+	fs.BoolVar(&a.verbose, "v", false, "log the requests and responses")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: ravectl %s [flags] %s\n\nFlags:\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses the command's flags and checks the output format
+func (a *app) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if a.output != "table" && a.output != "json" {
+		return a.usageError(fs, "--output must be table or json")
+	}
+	return nil
+}
+
+func (a *app) usageError(fs *flag.FlagSet, msg string) error {
+	fmt.Fprintf(a.stderr, "%s: %s\n", fs.Name(), msg)
+	fs.Usage()
+	return errUsage
+}
+
+// config is the keys and mode the client is built with
+type config struct {
+	PublicKey string
+	SecretKey string
+	Mode      string
+	BaseURL   string
+}
+
+// or fills the unset fields of the config from the other config
+func (c config) or(other config) config {
+	if c.PublicKey == "" {
+		c.PublicKey = other.PublicKey
+	}
+	if c.SecretKey == "" {
+		c.SecretKey = other.SecretKey
+	}
+	if c.Mode == "" {
+		c.Mode = other.Mode
+	}
+	if c.BaseURL == "" {
+		c.BaseURL = other.BaseURL
+	}
+	return c
+}
+
+// config resolves the keys and mode from the env vars, the profile file and the flags
+func (a *app) config() (config, error) {
+	env := config{PublicKey: a.getenv("RAVE_PUBLIC_KEY"), SecretKey: a.getenv("RAVE_SECRET_KEY"), Mode: a.getenv("RAVE_MODE")}
+
+	name := a.profile
+	if name == "" {
+		name = "default"
+	}
+	profile, err := loadProfile(a.profilePath(), name)
+	if err != nil && (a.profile != "" || !errors.Is(err, errNoProfile)) {
+		return config{}, err
+	}
+
+	cfg := env.or(profile)
+	if a.profile != "" {
+		cfg = profile.or(env)
+	}
+	if a.baseURL != "" {
+		cfg.BaseURL = a.baseURL
+	}
+	if cfg.PublicKey == "" || cfg.SecretKey == "" {
+		return config{}, errors.New("no keys, set RAVE_PUBLIC_KEY and RAVE_SECRET_KEY or add them to a profile")
+	}
+	return cfg, nil
+}
+
+func (a *app) profilePath() string {
+	if path := a.getenv("RAVECTL_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "ravectl", "profiles")
+}
+
+// errNoProfile is returned when the profile file or the profile in it doesn't exist
+var errNoProfile = errors.New("no such profile")
+
+// loadProfile reads the profile from the profile file
+// profiles are sections of key = value lines, lines starting with # or ; are comments
+func loadProfile(path, name string) (config, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) || path == "" {
+		return config{}, fmt.Errorf("%w %s: %s doesn't exist", errNoProfile, name, path)
+	}
+	if err != nil {
+		return config{}, err
+	}
+	defer f.Close()
+
+	var cfg config
+	found := false
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+			continue
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			section = strings.TrimSpace(text[1 : len(text)-1])
+			found = found || section == name
+			continue
+		}
+		if section != name {
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return config{}, fmt.Errorf("%s:%d: expected key = value", path, line)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "public_key":
+			cfg.PublicKey = value
+		case "secret_key":
+			cfg.SecretKey = value
+		case "mode":
+			cfg.Mode = value
+		case "base_url":
+			cfg.BaseURL = value
+		default:
+			return config{}, fmt.Errorf("%s:%d: unknown key %s", path, line, strings.TrimSpace(key))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return config{}, err
+	}
+	if !found {
+		return config{}, fmt.Errorf("%w %s in %s", errNoProfile, name, path)
+	}
+	return cfg, nil
+}
+
+// client returns a client with the resolved config
+// in dry runs the client's requests are captured instead of sent
+func (a *app) client() (*ravepay.Client, error) {
+	cfg, err := a.config()
+	if err != nil {
+		return nil, err
+	}
+
+	level := ravepay.LogLevelWarn
+	if a.verbose {
+		level = ravepay.LogLevelDebug
+	}
+	opts := []ravepay.ClientOption{ravepay.WithKeys(cfg.PublicKey, cfg.SecretKey), ravepay.WithMode(cfg.Mode)}
+	if cfg.BaseURL != "" {
+		opts = append(opts, ravepay.WithBaseURL(cfg.BaseURL))
+	}
+	if a.dryRun {
+		// the captured request fails the client's call, there's nothing to log about it
+		level = ravepay.LogLevelError + 1
+		a.dry = &dryRunTransport{secretKey: cfg.SecretKey}
+		opts = append(opts, ravepay.WithHTTPClient(&http.Client{Transport: a.dry}))
+	}
+	opts = append(opts, ravepay.WithLogger(ravepay.NewStdLogger(log.New(a.stderr, "", 0), level)))
+	return ravepay.NewClient(opts...), nil
+}
+
+// errDryRun fails the requests captured in dry runs
+var errDryRun = errors.New("dry run, the request wasn't sent")
+
+// dryRunTransport captures the request instead of sending it
+type dryRunTransport struct {
+	secretKey string
+	method    string
+	url       string
+	body      []byte
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.method, t.url = req.Method, req.URL.String()
+	if req.Body != nil {
+		t.body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+	return nil, errDryRun
+}
+
+// payload returns the captured body with the client of charges decrypted
+func (t *dryRunTransport) payload() interface{} {
+	if len(t.body) == 0 {
+		return nil
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(t.body, &payload); err != nil {
+		return string(t.body)
+	}
+	if client, ok := payload["client"].(string); ok {
+		if decrypted, err := ravepay.DecryptChargePayload(client, t.secretKey); err == nil && json.Valid(decrypted) {
+			payload["client"] = json.RawMessage(decrypted)
+		}
+	}
+	return payload
+}
+
+// done reports the outcome of the command's request
+// a request captured in a dry run is printed, and other errors are returned
+func (a *app) done(err error) error {
+	if a.dry == nil || !errors.Is(err, errDryRun) {
+		return err
+	}
+	if a.dry.method == "" {
+		return errors.New("dry run, no request was made")
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"method":  a.dry.method,
+		"url":     a.dry.url,
+		"payload": a.dry.payload(),
+	})
+	if err != nil {
+		return err
+	}
+	// secrets are masked as in the client's logs
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(ravepay.Redact(string(b))), "", "  "); err != nil {
+		return err
+	}
+	fmt.Fprintln(a.stdout, out.String())
+	return nil
+}
+
+// row is a field of a table
+type row struct {
+	name  string
+	value interface{}
+}
+
+// print prints the response as json, or the rows as a table
+func (a *app) print(resp interface{}, rows []row) error {
+	if a.output == "json" {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(resp)
+	}
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%v\n", r.name, r.value)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ravepay "github.com/0sc/rave"
+	"github.com/0sc/rave/ravetest"
+)
+
+// testExpiryYear is the expiry year of the test cards, a year from now so they never expire
+var testExpiryYear = time.Now().AddDate(1, 0, 0).Format("06")
+
+// flatten collapses the table padding so rows can be matched as "name value"
+func flatten(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func testEnv(srv *ravetest.Server) func(string) string {
+	env := map[string]string{
+		"RAVE_PUBLIC_KEY": srv.PublicKey,
+		"RAVE_SECRET_KEY": srv.SecretKey,
+		"RAVECTL_CONFIG":  filepath.Join(os.TempDir(), "ravectl-no-such-profiles"),
+	}
+	return func(key string) string { return env[key] }
+}
+
+func TestRun(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+	c := ravepay.NewClient(ravepay.WithKeys(srv.PublicKey, srv.SecretKey), ravepay.WithBaseURL(srv.URL))
+
+	card := &ravepay.Card{CardNo: "5840406187553286", Cvv: "116", Expirymonth: "09", Expiryyear: testExpiryYear, Currency: "NGN", Country: "NG"}
+	charged, err := c.Charge(&ravepay.ChargeRequest{TxRef: "MXX-charged", Amount: ravepay.NewMoney(300000, "NGN"), Email: "tester@flutter.co"}, card)
+	if err != nil {
+		t.Fatalf("charging the card failed: %v", err)
+	}
+	preauth := c.NewPreAuth(&ravepay.ChargeRequest{TxRef: "MXX-preauth", Amount: ravepay.NewMoney(300000, "NGN"), Email: "tester@flutter.co"}, card)
+	if _, err := preauth.Authorize(); err != nil {
+		t.Fatalf("authorizing the preauth failed: %v", err)
+	}
+	flwRef := charged.Data.FlwRef
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		want     []string
+	}{
+		{
+			name:     "verifies a transaction",
+			args:     []string{"verify", "--flwref", flwRef, "--amount", "3000", "--currency", "NGN"},
+			wantCode: 0,
+			want:     []string{"flw_ref " + flwRef, "status successful", "verified true"},
+		},
+		{
+			name:     "fails a transaction that doesn't verify",
+			args:     []string{"verify", "--flwref", flwRef, "--amount", "4000", "--currency", "NGN"},
+			wantCode: 1,
+			want:     []string{"verified false", "failed AmountVerificationFailed"},
+		},
+		{
+			name:     "verifies a transaction by txref against a policy",
+			args:     []string{"verify", "--txref", "MXX-charged", "--by", "txref", "--amount", "3000", "--currency", "NGN", "--exact", "--fraud-status", "ok", "--payment-entity", "card"},
+			wantCode: 0,
+			want:     []string{"tx_ref MXX-charged", "verified true"},
+		},
+		{
+			name:     "fails a transaction that doesn't pass the policy",
+			args:     []string{"verify", "--flwref", flwRef, "--amount", "3000", "--currency", "NGN", "--payment-entity", "account"},
+			wantCode: 1,
+			want:     []string{"verified false", "failed PaymentEntityVerificationFailed: expected account but got card"},
+		},
+		{
+			name:     "rejects unknown references to verify by",
+			args:     []string{"verify", "--flwref", flwRef, "--amount", "3000", "--currency", "NGN", "--by", "orderref"},
+			wantCode: 2,
+		},
+		{
+			name:     "verifies a transaction with xrequery as json",
+			args:     []string{"xrequery", "--flwref", flwRef, "--amount", "3000", "--currency", "NGN", "-o", "json"},
+			wantCode: 0,
+			want:     []string{`"verified": true`, `"txref": "MXX-charged"`, `"name": "amount"`},
+		},
+		{
+			name:     "requires a reference to verify",
+			args:     []string{"verify", "--amount", "3000"},
+			wantCode: 2,
+		},
+		{
+			name:     "requires an amount to verify",
+			args:     []string{"verify", "--flwref", flwRef},
+			wantCode: 2,
+		},
+		{
+			name:     "requires a currency to verify with xrequery",
+			args:     []string{"xrequery", "--txref", "MXX-charged", "--amount", "3000"},
+			wantCode: 2,
+		},
+		{
+			name:     "lists the banks",
+			args:     []string{"banks"},
+			wantCode: 0,
+			want:     []string{"CODE NAME", "044 ACCESS BANK NIGERIA"},
+		},
+		{
+			name:     "gets the fee",
+			args:     []string{"fee", "--amount", "1000"},
+			wantCode: 0,
+			want:     []string{"charge_amount 1014", "fee 14"},
+		},
+		{
+			name:     "gets the exchange rate",
+			args:     []string{"forex", "--from", "USD", "--to", "NGN", "--amount", "10"},
+			wantCode: 0,
+			want:     []string{"rate 360", "converted_amount 3600"},
+		},
+		{
+			name:     "rejects capturing more than the authorized amount",
+			args:     []string{"preauth", "capture", "--flwref", preauth.FlwRef, "--amount", "5000"},
+			wantCode: 1,
+		},
+		{
+			name:     "rejects capture amounts with a fraction of the currency",
+			args:     []string{"preauth", "capture", "--flwref", preauth.FlwRef, "--amount", "1000.5", "--currency", "UGX"},
+			wantCode: 2,
+		},
+		{
+			name:     "captures part of a preauth",
+			args:     []string{"preauth", "capture", "--flwref", preauth.FlwRef, "--amount", "1000", "--currency", "NGN"},
+			wantCode: 0,
+			want:     []string{"status successful", "charge_response 00"},
+		},
+		{
+			name:     "rejects unknown preauth actions",
+			args:     []string{"preauth", "hold", "--flwref", preauth.FlwRef},
+			wantCode: 2,
+		},
+		{
+			name:     "refunds a transaction",
+			args:     []string{"refund", "--flwref", flwRef},
+			wantCode: 0,
+			want:     []string{"amount_refunded 3000", "status completed"},
+		},
+		{
+			name:     "reports rave's errors",
+			args:     []string{"refund", "--flwref", "FLW-MOCK-unknown"},
+			wantCode: 1,
+		},
+		{
+			name:     "charges a card through to the otp",
+			args:     []string{"charge", "--txref", "MXX-cli", "--amount", "100", "--email", "tester@flutter.co", "--cardno", "5438898014560229", "--cvv", "789", "--expiry", "09/" + testExpiryYear, "--pin", "3310", "--otp", ravetest.OTP},
+			wantCode: 0,
+			want:     []string{"tx_ref MXX-cli", "validation success Charge Complete", "next done"},
+		},
+		{
+			name:     "calculates a checksum",
+			args:     []string{"checksum", "--payload", `{"amount":2000,"currency":"NGN","txref":"MG-1500041286295"}`},
+			wantCode: 0,
+			want:     []string{"checksum "},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append(tt.args, "--base-url", srv.URL)
+			if code := run(context.Background(), args, testEnv(srv), &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d\nstdout: %s\nstderr: %s", code, tt.wantCode, stdout.String(), stderr.String())
+			}
+			out := flatten(stdout.String())
+			for _, want := range tt.want {
+				if !strings.Contains(out, flatten(want)) && !strings.Contains(stdout.String(), want) {
+					t.Errorf("run() printed %s, want %s", stdout.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestRun_dryRun(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"charge", "--dry-run", "--base-url", srv.URL, "--txref", "MXX-dry", "--amount", "100", "--email", "tester@flutter.co", "--cardno", "5438898014560229", "--cvv", "789", "--expiry", "09/" + testExpiryYear}
+	if code := run(context.Background(), args, testEnv(srv), &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr: %s", code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{`"method": "POST"`, `"txRef": "MXX-dry"`, `"payment_type": "card"`, `"cardno": "[REDACTED]"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("the dry run printed %s, want %s", out, want)
+		}
+	}
+	if strings.Contains(out, "5438898014560229") {
+		t.Errorf("the dry run printed the card number: %s", out)
+	}
+	if n := srv.Requests(ravetest.EndpointCharge); n != 0 {
+		t.Errorf("the dry run sent %d charges", n)
+	}
+}
+
+func TestRun_usage(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{name: "no command", args: nil, wantCode: 2},
+		{name: "help", args: []string{"help"}, wantCode: 0},
+		{name: "unknown command", args: []string{"settle"}, wantCode: 2},
+		{name: "command help", args: []string{"verify", "-h"}, wantCode: 0},
+		{name: "unknown output", args: []string{"banks", "-o", "yaml"}, wantCode: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(context.Background(), tt.args, func(string) string { return "" }, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("run() = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(stderr.String(), "Usage: ravectl") {
+				t.Errorf("run() didn't print the usage: %s", stderr.String())
+			}
+		})
+	}
+}
+
+func TestApp_config(t *testing.T) {
+	profiles := filepath.Join(t.TempDir(), "profiles")
+	err := os.WriteFile(profiles, []byte(`# ravectl profiles
+[default]
+public_key = FLWPUBK-default-X
+secret_key = FLWSECK-default-X
+
+[live]
+public_key = FLWPUBK-live-X
+secret_key = FLWSECK-live-X
+mode = live
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		profile string
+		want    config
+		wantErr bool
+	}{
+		{
+			name: "reads the keys and mode from the env",
+			env:  map[string]string{"RAVE_PUBLIC_KEY": "FLWPUBK-env-X", "RAVE_SECRET_KEY": "FLWSECK-env-X", "RAVE_MODE": "live"},
+			want: config{PublicKey: "FLWPUBK-env-X", SecretKey: "FLWSECK-env-X", Mode: "live"},
+		},
+		{
+			name: "fills in what the env doesn't set from the default profile",
+			env:  map[string]string{"RAVE_PUBLIC_KEY": "FLWPUBK-env-X"},
+			want: config{PublicKey: "FLWPUBK-env-X", SecretKey: "FLWSECK-default-X"},
+		},
+		{
+			name:    "prefers a picked profile to the env",
+			env:     map[string]string{"RAVE_PUBLIC_KEY": "FLWPUBK-env-X", "RAVE_SECRET_KEY": "FLWSECK-env-X"},
+			profile: "live",
+			want:    config{PublicKey: "FLWPUBK-live-X", SecretKey: "FLWSECK-live-X", Mode: "live"},
+		},
+		{
+			name:    "fails for a missing profile",
+			env:     map[string]string{},
+			profile: "staging",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.env["RAVECTL_CONFIG"] = profiles
+			a := &app{getenv: func(key string) string { return tt.env[key] }, profile: tt.profile}
+			got, err := a.config()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("config() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("config() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,30 @@ const (
 	getFeeURL                = "/flwv3-pug/getpaidx/api/fee"
 	refundTxnURL             = "/gpx/merchant/transactions/refund"
 	forexURL                 = "/flwv3-pug/getpaidx/api/forex"
+	tokenizedChargeURL       = "/flwv3-pug/getpaidx/api/tokenized/charge"
+	bulkTokenizedChargeURL   = "/flwv3-pug/getpaidx/api/tokenized/charge_bulk"
+	resolveAccountURL        = "/flwv3-pug/getpaidx/api/resolve_account"
+	verifyBVNURL             = "/v2/kyc/bvn/%s"
+	lookupBINURL             = "/v2/gpx/bins/%s"
+
+	transfersURL          = "/v2/gpx/transfers"
+	createTransferURL     = "/v2/gpx/transfers/create"
+	createBulkTransferURL = "/v2/gpx/transfers/create_bulk"
+	transferFeeURL        = "/v2/gpx/transfers/fee"
+
+	createPaymentPlanURL    = "/v2/gpx/paymentplans/create"
+	queryPaymentPlansURL    = "/v2/gpx/paymentplans/query"
+	editPaymentPlanURL      = "/v2/gpx/paymentplans/%d/edit"
+	cancelPaymentPlanURL    = "/v2/gpx/paymentplans/%d/cancel"
+	querySubscriptionsURL   = "/v2/gpx/subscriptions/query"
+	cancelSubscriptionURL   = "/v2/gpx/subscriptions/%d/cancel"
+	activateSubscriptionURL = "/v2/gpx/subscriptions/%d/activate"
+
+	subaccountsURL      = "/v2/gpx/subaccounts"
+	createSubaccountURL = "/v2/gpx/subaccounts/create"
+	getSubaccountURL    = "/v2/gpx/subaccounts/get/%s"
+	editSubaccountURL   = "/v2/gpx/subaccounts/edit"
+	deleteSubaccountURL = "/v2/gpx/subaccounts/delete"
 
 	testModeBaseURL = "http://flw-pms-dev.eu-west-1.elasticbeanstalk.com"
 	liveModeBaseURL = "https://api.ravepay.co"
@@ -0,0 +1,152 @@
+package ravepay
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency rave accepts payments in
+type Currency struct {
+	Code string
+	Name string
+	// Exponent is the number of decimal places the currency's amounts have e.g 2 for NGN, 0 for UGX
+	Exponent int
+}
+
+// Country is an ISO 3166 country rave accepts payments from, with its local currency
+type Country struct {
+	Code     string
+	Name     string
+	Currency string
+}
+
+var currencies = map[string]Currency{
+	"EUR": {Code: "EUR", Name: "Euro", Exponent: 2},
+	"GBP": {Code: "GBP", Name: "Pound Sterling", Exponent: 2},
+	"GHS": {Code: "GHS", Name: "Ghana Cedi", Exponent: 2},
+	"KES": {Code: "KES", Name: "Kenyan Shilling", Exponent: 2},
+	"MWK": {Code: "MWK", Name: "Malawi Kwacha", Exponent: 2},
+	"NGN": {Code: "NGN", Name: "Naira", Exponent: 2},
+	"RWF": {Code: "RWF", Name: "Rwanda Franc", Exponent: 0},
+	"SLL": {Code: "SLL", Name: "Leone", Exponent: 2},
+	"TZS": {Code: "TZS", Name: "Tanzanian Shilling", Exponent: 2},
+	"UGX": {Code: "UGX", Name: "Uganda Shilling", Exponent: 0},
+	"USD": {Code: "USD", Name: "US Dollar", Exponent: 2},
+	"XAF": {Code: "XAF", Name: "CFA Franc BEAC", Exponent: 0},
+	"XOF": {Code: "XOF", Name: "CFA Franc BCEAO", Exponent: 0},
+	"ZAR": {Code: "ZAR", Name: "Rand", Exponent: 2},
+	"ZMW": {Code: "ZMW", Name: "Zambian Kwacha", Exponent: 2},
+}
+
+var countries = map[string]Country{
+	"CI": {Code: "CI", Name: "Côte d'Ivoire", Currency: "XOF"},
+	"CM": {Code: "CM", Name: "Cameroon", Currency: "XAF"},
+	"GB": {Code: "GB", Name: "United Kingdom", Currency: "GBP"},
+	"GH": {Code: "GH", Name: "Ghana", Currency: "GHS"},
+	"KE": {Code: "KE", Name: "Kenya", Currency: "KES"},
+	"MW": {Code: "MW", Name: "Malawi", Currency: "MWK"},
+	"NG": {Code: "NG", Name: "Nigeria", Currency: "NGN"},
+	"RW": {Code: "RW", Name: "Rwanda", Currency: "RWF"},
+	"SL": {Code: "SL", Name: "Sierra Leone", Currency: "SLL"},
+	"SN": {Code: "SN", Name: "Senegal", Currency: "XOF"},
+	"TZ": {Code: "TZ", Name: "Tanzania", Currency: "TZS"},
+	"UG": {Code: "UG", Name: "Uganda", Currency: "UGX"},
+	"US": {Code: "US", Name: "United States", Currency: "USD"},
+	"ZA": {Code: "ZA", Name: "South Africa", Currency: "ZAR"},
+	"ZM": {Code: "ZM", Name: "Zambia", Currency: "ZMW"},
+}
+
+// LookupCurrency returns the currency with the ISO 4217 code, it reports false if rave doesn't accept the currency
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// LookupCountry returns the country with the ISO 3166 alpha-2 code, it reports false if rave doesn't accept payments from the country
+func LookupCountry(code string) (Country, bool) {
+	c, ok := countries[strings.ToUpper(code)]
+	return c, ok
+}
+
+// Currencies returns the currencies rave accepts, sorted by code
+func Currencies() []Currency {
+	list := make([]Currency, 0, len(currencies))
+	for _, c := range currencies {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// Countries returns the countries rave accepts payments from, sorted by code
+func Countries() []Country {
+	list := make([]Country, 0, len(countries))
+	for _, c := range countries {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// market is a country and currency combination a payment method accepts
+type market struct {
+	country  string
+	currency string
+}
+
+// validateMarket checks the country and currency are a combination the payment method accepts
+// An empty country or currency is left for rave to default, and a nil markets accepts any registered combination
+func validateMarket(method, country, currency string, markets []market) error {
+	if country != "" {
+		if _, ok := LookupCountry(country); !ok {
+			return &ValidationError{Field: "country", Message: fmt.Sprintf("%s isn't a country rave accepts payments from", country)}
+		}
+	}
+	if currency != "" {
+		if _, ok := LookupCurrency(currency); !ok {
+			return &ValidationError{Field: "currency", Message: fmt.Sprintf("%s isn't a currency rave accepts", currency)}
+		}
+	}
+	if markets == nil {
+		return nil
+	}
+
+	for _, m := range markets {
+		if (country == "" || strings.EqualFold(country, m.country)) && (currency == "" || strings.EqualFold(currency, m.currency)) {
+			return nil
+		}
+	}
+	field := "country"
+	if currency != "" {
+		field = "currency"
+	}
+	return &ValidationError{Field: field, Message: fmt.Sprintf("%s charges only accept %s", method, marketList(markets))}
+}
+
+func marketList(markets []market) string {
+	list := make([]string, len(markets))
+	for i, m := range markets {
+		list[i] = m.currency + " in " + m.country
+	}
+	return strings.Join(list, ", ")
+}
+
+// validateAmountPrecision checks the amount has no more decimal places than its currency e.g no fraction of UGX
+// Amounts with a currency are in its minor units, and ParseMoney rejects fractions of them,
+// so only amounts without one, in hundredths, can have a fraction too many
+func validateAmountPrecision(amount Money, currency string) error {
+	c, ok := LookupCurrency(currency)
+	if !ok || c.Exponent >= 2 || amount.Currency != "" {
+		return nil
+	}
+
+	unit := int64(1)
+	for i := c.Exponent; i < 2; i++ {
+		unit *= 10
+	}
+	if amount.Minor%unit != 0 {
+		return &ValidationError{Field: "amount", Message: fmt.Sprintf("%s amounts can't have more than %d decimal places", c.Code, c.Exponent)}
+	}
+	return nil
+}
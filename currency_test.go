@@ -0,0 +1,122 @@
+package ravepay
+
+import (
+	"errors"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	if got, ok := LookupCurrency("ugx"); !ok || got.Code != "UGX" || got.Exponent != 0 {
+		t.Errorf("LookupCurrency(ugx) = %+v, %v", got, ok)
+	}
+	if got, ok := LookupCurrency("NGN"); !ok || got.Exponent != 2 {
+		t.Errorf("LookupCurrency(NGN) = %+v, %v", got, ok)
+	}
+	if _, ok := LookupCurrency("BTC"); ok {
+		t.Errorf("LookupCurrency(BTC) found an unsupported currency")
+	}
+
+	if got, ok := LookupCountry("ke"); !ok || got.Code != "KE" || got.Currency != "KES" {
+		t.Errorf("LookupCountry(ke) = %+v, %v", got, ok)
+	}
+	if _, ok := LookupCountry("XX"); ok {
+		t.Errorf("LookupCountry(XX) found an unsupported country")
+	}
+
+	list := Currencies()
+	if len(list) != len(currencies) || !sort.SliceIsSorted(list, func(i, j int) bool { return list[i].Code < list[j].Code }) {
+		t.Errorf("Currencies() = %v, want all currencies sorted by code", list)
+	}
+	for _, c := range Countries() {
+		if _, ok := LookupCurrency(c.Currency); !ok {
+			t.Errorf("country %s has unregistered currency %s", c.Code, c.Currency)
+		}
+	}
+}
+
+func Test_validateMarket(t *testing.T) {
+	tests := []struct {
+		name      string
+		country   string
+		currency  string
+		markets   []market
+		wantField string
+	}{
+		{name: "accepts a market of the payment method", country: "KE", currency: "KES", markets: mpesaMarkets},
+		{name: "accepts lowercase codes", country: "ke", currency: "kes", markets: mpesaMarkets},
+		{name: "leaves an empty country and currency to rave", markets: mpesaMarkets},
+		{name: "accepts any registered market without markets", country: "US", currency: "NGN"},
+		{name: "rejects a currency the payment method doesn't accept", country: "KE", currency: "NGN", markets: mpesaMarkets, wantField: "currency"},
+		{name: "rejects a country the payment method doesn't accept", country: "NG", markets: mpesaMarkets, wantField: "country"},
+		{name: "rejects an unregistered currency", currency: "BTC", wantField: "currency"},
+		{name: "rejects an unregistered country", country: "XX", wantField: "country"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMarket("test", tt.country, tt.currency, tt.markets)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("validateMarket() error = %v", err)
+				}
+				return
+			}
+
+			var vErr *ValidationError
+			if !errors.As(err, &vErr) || vErr.Field != tt.wantField {
+				t.Errorf("validateMarket() error = %v, want ValidationError for %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func Test_validateAmountPrecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		currency string
+		wantErr  bool
+	}{
+		{name: "accepts whole amounts of zero decimal currencies", amount: NewMoney(100000, ""), currency: "UGX"},
+		{name: "rejects fractions of zero decimal currencies", amount: NewMoney(100050, ""), currency: "UGX", wantErr: true},
+		{name: "accepts amounts in minor units of zero decimal currencies", amount: NewMoney(1000, "UGX"), currency: "UGX"},
+		{name: "accepts fractions of two decimal currencies", amount: NewMoney(100050, "NGN"), currency: "NGN"},
+		{name: "leaves unregistered currencies to rave", amount: NewMoney(100050, ""), currency: "BTC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateAmountPrecision(tt.amount, tt.currency); (err != nil) != tt.wantErr {
+				t.Errorf("validateAmountPrecision() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Charge_validatesMarket(t *testing.T) {
+	handler := &testServer{resp: []byte(successfulMpesaChargeResponse)}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		chargeable Chargeable
+	}{
+		{name: "mpesa in NGN", chargeable: &Mpesa{Currency: "NGN", Country: "KE", ChargeMpesaURL: server.URL}},
+		{name: "ghana mobile money from NG", chargeable: &MobileMoneyGH{Network: "MTN", Currency: "GHS", Country: "NG", ChargeRequestURL: server.URL}},
+		{name: "ussd in KES", chargeable: &USSD{AccountBank: "044", Currency: "KES", Country: "NG", ChargeRequestURL: server.URL}},
+		{name: "account in GHS", chargeable: &Account{AccountBank: "044", AccountNumber: "0690000031", Currency: "GHS", Country: "GH", ChargeAccountURL: server.URL}},
+		{name: "card in an unsupported currency", chargeable: &Card{CardNo: "5438898014560229", Cvv: "789", Expirymonth: "09", Expiryyear: testExpiryYear, Currency: "BTC", ChargeCardURL: server.URL}},
+	}
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &ChargeRequest{Amount: NewMoney(30000, ""), Email: "tester@flutter.co", TxRef: "MXX-ASC-4578"}
+			_, err := c.Charge(cr, tt.chargeable)
+			var vErrs ValidationErrors
+			if !errors.As(err, &vErrs) || len(vErrs) != 1 {
+				t.Errorf("Client.Charge() error = %v, want a single ValidationError", err)
+			}
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
-	"log"
 	"strings"
 )
 
@@ -27,11 +26,11 @@ func getEncryptionKey(seckey string) string {
 }
 
 // https://github.com/golang/go/issues/5597
-func tripleDESEncrypt(payload, key []byte) string {
+// it returns an error if the key isn't a valid 3DES key
+func tripleDESEncrypt(payload, key []byte) (string, error) {
 	block, err := des.NewTripleDESCipher(key)
 	if err != nil {
-		log.Println("couldn't create 3DESC cipher: ", err)
-		return ""
+		return "", fmt.Errorf("couldn't create 3DESC cipher: %w", err)
 	}
 
 	bs := block.BlockSize()
@@ -49,5 +48,40 @@ func tripleDESEncrypt(payload, key []byte) string {
 		cipher = cipher[bs:]
 	}
 
-	return base64.StdEncoding.EncodeToString(cipherDup)
+	return base64.StdEncoding.EncodeToString(cipherDup), nil
+}
+
+// DecryptChargePayload decrypts the client of a charge request encrypted with the secret key
+// It's for inspecting what a charge sends to rave e.g in dry runs and tests
+func DecryptChargePayload(client, secretKey string) ([]byte, error) {
+	cipher, err := base64.StdEncoding.DecodeString(client)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode the encrypted payload: %w", err)
+	}
+	return tripleDESDecrypt(cipher, []byte(getEncryptionKey(secretKey)))
+}
+
+// tripleDESDecrypt reverses tripleDESEncrypt
+// payloads are only padded if they aren't a multiple of the block size, so the padding is only stripped
+// if the trailing bytes are valid padding, json payloads end in } which can't be mistaken for it
+func tripleDESDecrypt(cipher, key []byte) ([]byte, error) {
+	block, err := des.NewTripleDESCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create 3DESC cipher: %w", err)
+	}
+
+	bs := block.BlockSize()
+	if len(cipher) == 0 || len(cipher)%bs != 0 {
+		return nil, fmt.Errorf("the encrypted payload isn't a multiple of the %d bytes block size", bs)
+	}
+
+	payload := make([]byte, len(cipher))
+	for i := 0; i < len(cipher); i += bs {
+		block.Decrypt(payload[i:i+bs], cipher[i:i+bs])
+	}
+
+	if n := int(payload[len(payload)-1]); n > 0 && n < bs && bytes.Equal(payload[len(payload)-n:], bytes.Repeat([]byte{byte(n)}, n)) {
+		payload = payload[:len(payload)-n]
+	}
+	return payload, nil
 }
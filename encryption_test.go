@@ -66,9 +66,54 @@ func Test_tripleDESEncrypt(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := tripleDESEncrypt(tt.args.payload, tt.args.key); got != tt.want {
+			if got, _ := tripleDESEncrypt(tt.args.payload, tt.args.key); got != tt.want {
 				t.Errorf("tripleDESEncrypt() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestDecryptChargePayload(t *testing.T) {
+	seckey := "FLWSECK-6b32914d4d60c10d0ef72bdad734134a-X"
+	tests := []struct {
+		name    string
+		client  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "decrypts a payload of whole blocks",
+			client: "9fx+9uGjG+Oikq8syKpfeg==",
+			want:   "A 16 byte string",
+		},
+		{
+			name: "strips the padding",
+			want: `{"cardno":"5438898014560229"}`,
+		},
+		{
+			name:    "rejects payloads that aren't base64",
+			client:  "not-base64!",
+			wantErr: true,
+		},
+		{
+			name:    "rejects payloads that aren't whole blocks",
+			client:  "AAAA",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := tt.client
+			if client == "" {
+				client, _ = tripleDESEncrypt([]byte(tt.want), []byte(getEncryptionKey(seckey)))
+			}
+			got, err := DecryptChargePayload(client, seckey)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecryptChargePayload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("DecryptChargePayload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
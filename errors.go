@@ -0,0 +1,210 @@
+package ravepay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Sentinel errors classifying the failures reported by the rave api
+// They are meant to be used with errors.Is on errors returned from any api call
+var (
+	// ErrAuthentication reports that rave rejected the keys used for the request
+	ErrAuthentication = errors.New("ravepay: authentication failed")
+	// ErrValidation reports that the request params were rejected as invalid
+	ErrValidation = errors.New("ravepay: validation failed")
+	// ErrDeclined reports that the transaction was declined
+	ErrDeclined = errors.New("ravepay: transaction declined")
+	// ErrRateLimited reports that rave throttled the request
+	ErrRateLimited = errors.New("ravepay: rate limited")
+	// ErrServer reports that rave failed to process the request on its end
+	ErrServer = errors.New("ravepay: server error")
+)
+
+// APIError is returned whenever a request to rave fails in transport
+// or rave reports a status other than success
+// It carries enough of the exchange to tell what went wrong and where
+type APIError struct {
+	// HTTPStatus is the http status code of the response, it is 0 if no response was received
+	HTTPStatus int
+	// Status is rave's status in the response body e.g error
+	Status string
+	// Message is rave's message in the response body
+	Message string
+	// Method is the http method of the failed request
+	Method string
+	// Endpoint is the url of the failed request with its secret key and other sensitive params masked
+	Endpoint string
+	// Body is the raw response body
+	Body []byte
+	// Err is the underlying transport error if any
+	Err error
+}
+
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("RaveAPIError: %s %s: %v", e.Method, e.Endpoint, e.Err)
+	}
+	return fmt.Sprintf("RaveAPIError: %s %s: http status %d, rave status %s: %s", e.Method, e.Endpoint, e.HTTPStatus, e.Status, e.Message)
+}
+
+// Unwrap returns the underlying transport error if any
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error falls in the class of the given sentinel error
+// e.g errors.Is(err, ErrDeclined)
+func (e *APIError) Is(target error) bool {
+	kind := e.Kind()
+	return kind != nil && kind == target
+}
+
+// Kind returns the sentinel error classifying this error
+// It returns nil for transport errors, and errors that can't be classified
+func (e *APIError) Kind() error {
+	if e.Err != nil {
+		return nil
+	}
+
+	switch {
+	case e.HTTPStatus == http.StatusUnauthorized || e.HTTPStatus == http.StatusForbidden:
+		return ErrAuthentication
+	case e.HTTPStatus == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.HTTPStatus >= http.StatusInternalServerError:
+		return ErrServer
+	}
+
+	msg := strings.ToLower(e.Message)
+	for _, hint := range declineHints {
+		if strings.Contains(msg, hint) {
+			return ErrDeclined
+		}
+	}
+	for _, hint := range authHints {
+		if strings.Contains(msg, hint) {
+			return ErrAuthentication
+		}
+	}
+
+	return ErrValidation
+}
+
+// declineHints and authHints are fragments of the messages rave returns (with a 200 status)
+// for declined transactions and rejected keys respectively
+var (
+	declineHints = []string{"declined", "insufficient", "do not honor", "not permitted", "restricted card", "expired card", "stolen", "lost card"}
+	authHints    = []string{"invalid public key", "invalid secret key", "invalid seckey", "unauthorized", "unauthorised", "authentication"}
+)
+
+// ValidationError is returned when a request is rejected client side, before it is sent to rave
+// It satisfies errors.Is(err, ErrValidation)
+type ValidationError struct {
+	// Field is the name of the invalid request field
+	Field string
+	// Message describes why the field is invalid
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("ValidationError: %s %s", e.Field, e.Message)
+}
+
+// Is reports whether the target is ErrValidation
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ValidationErrors is returned when several fields of a request are invalid
+// It satisfies errors.Is(err, ErrValidation), and errors.As finds its first ValidationError
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Field + " " + err.Message
+	}
+	return "ValidationError: " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether the target is ErrValidation
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Unwrap returns the validation errors of the fields
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// add appends the validation errors in err, other errors are added as an error of the given field
+func (e ValidationErrors) add(field string, err error) ValidationErrors {
+	switch err := err.(type) {
+	case nil:
+		return e
+	case *ValidationError:
+		return append(e, err)
+	case ValidationErrors:
+		return append(e, err...)
+	}
+	return append(e, &ValidationError{Field: field, Message: err.Error()})
+}
+
+// err returns the validation errors as an error, or nil if there are none
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// responseEnvelope is the part of the response body common to rave api responses
+type responseEnvelope struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// checkResponse returns an APIError if the http status or rave status in the response body reports a failure
+// bodies that aren't json objects, like the list banks response, are only checked by http status
+func checkResponse(mtd, url string, statusCode int, body []byte) error {
+	env := responseEnvelope{}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		json.Unmarshal(trimmed, &env)
+	}
+
+	if statusCode < http.StatusBadRequest && (env.Status == "" || env.Status == "success") {
+		return nil
+	}
+
+	return &APIError{
+		HTTPStatus: statusCode,
+		Status:     env.Status,
+		Message:    env.Message,
+		Method:     mtd,
+		Endpoint:   redactURL(url),
+		Body:       body,
+	}
+}
+
+// redactURL masks the secret key and other sensitive params in the query of the url
+// GET endpoints take the secret key in the query, so it mustn't end up in error messages
+func redactURL(rawURL string) string {
+	return Redact(rawURL)
+}
+
+// redactTransportError masks the url carried by the transport error
+func redactTransportError(err error) error {
+	if ue, ok := err.(*url.Error); ok {
+		return &url.Error{Op: ue.Op, URL: redactURL(ue.URL), Err: ue.Err}
+	}
+	return err
+}
@@ -0,0 +1,140 @@
+package ravepay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAPIError_Kind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *APIError
+		want error
+	}{
+		{
+			name: "classifies 401 as authentication failure",
+			err:  &APIError{HTTPStatus: 401},
+			want: ErrAuthentication,
+		},
+		{
+			name: "classifies 429 as rate limited",
+			err:  &APIError{HTTPStatus: 429},
+			want: ErrRateLimited,
+		},
+		{
+			name: "classifies 5xx as server error",
+			err:  &APIError{HTTPStatus: 502},
+			want: ErrServer,
+		},
+		{
+			name: "classifies decline messages as declined",
+			err:  &APIError{HTTPStatus: 200, Status: "error", Message: "Insufficient Funds"},
+			want: ErrDeclined,
+		},
+		{
+			name: "classifies key rejections as authentication failure",
+			err:  &APIError{HTTPStatus: 200, Status: "error", Message: "Invalid public key passed"},
+			want: ErrAuthentication,
+		},
+		{
+			name: "classifies other rave errors as validation errors",
+			err:  &APIError{HTTPStatus: 200, Status: "error", Message: "accountnumber is required"},
+			want: ErrValidation,
+		},
+		{
+			name: "doesn't classify transport errors",
+			err:  &APIError{Err: errors.New("connection reset by peer")},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Kind(); got != tt.want {
+				t.Errorf("APIError.Kind() = %v, want %v", got, tt.want)
+			}
+			if tt.want != nil && !errors.Is(tt.err, tt.want) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", tt.err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIError_fromRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"status":"error","message":"Bad Gateway"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL))
+	resp, err := c.GetFee(&GetFeeRequest{Amount: NewMoney(100000, "NGN"), Currency: "NGN"})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Client.GetFee() error = %v, want *APIError", err)
+	}
+	if apiErr.HTTPStatus != http.StatusBadGateway || apiErr.Message != "Bad Gateway" || apiErr.Endpoint != server.URL+getFeeURL {
+		t.Errorf("Client.GetFee() error = %+v", apiErr)
+	}
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("errors.Is(%v, ErrServer) = false, want true", err)
+	}
+	if resp.Status != "error" {
+		t.Errorf("Client.GetFee() status = %s, want the decoded error response", resp.Status)
+	}
+
+	server.Close()
+	_, err = c.ListBanks()
+	if !errors.As(err, &apiErr) || apiErr.Err == nil {
+		t.Errorf("Client.ListBanks() error = %v, want *APIError wrapping the transport error", err)
+	}
+}
+
+func TestAPIError_redactsEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"error","message":"Invalid page"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithKeys(PublicKey, SecretKey), WithBaseURL(server.URL))
+	endpoint := server.URL + "/v2/gpx/transfers?page=2&seckey=" + SecretKey
+	err := c.sendRequestAndParseResponse(context.Background(), "GET", endpoint, nil, &struct{}{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("sendRequestAndParseResponse() error = %v, want *APIError", err)
+	}
+	if want := server.URL + "/v2/gpx/transfers?page=2&seckey=[REDACTED]"; apiErr.Endpoint != want {
+		t.Errorf("APIError.Endpoint = %s, want %s", apiErr.Endpoint, want)
+	}
+
+	server.Close()
+	err = c.sendRequestAndParseResponse(context.Background(), "GET", endpoint, nil, &struct{}{})
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Fatalf("sendRequestAndParseResponse() error = %v, want a wrapped *url.Error", err)
+	}
+	for _, s := range []string{err.Error(), urlErr.URL} {
+		if strings.Contains(s, SecretKey) {
+			t.Errorf("the transport error has the secret key: %s", s)
+		}
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := error(&ValidationError{Field: "subaccounts", Message: "flat splits exceed the charge amount"})
+
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("errors.Is(%v, ErrValidation) = false, want true", err)
+	}
+	if errors.Is(err, ErrDeclined) {
+		t.Errorf("errors.Is(%v, ErrDeclined) = true, want false", err)
+	}
+	if want := "ValidationError: subaccounts flat splits exceed the charge amount"; err.Error() != want {
+		t.Errorf("ValidationError.Error() = %s, want %s", err.Error(), want)
+	}
+}
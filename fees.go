@@ -1,25 +1,45 @@
 package ravepay
 
+import "context"
+
 // FIXME: Done to enable testing
 var feeURL = buildURL(getFeeURL)
 
 // GetFeeRequest encapsulates the params need for requesting fee amount from the rave api
 // https://flutterwavedevelopers.readme.io/v2.0/reference#get-fees
 type GetFeeRequest struct {
-	Amount    string `json:"amount"`
+	Amount    Money  `json:"amount"`
 	PBFPubKey string `json:"PBFPubKey"`
 	Currency  string `json:"currency"`
 	PType     string `json:"ptype,omitempty"`
 	Card6     string `json:"card6,omitempty"`
 }
 
+// Validate checks the fee request has a positive amount in a currency rave accepts
+// and that card6, if set, is the first 6 digits of a card
+func (p *GetFeeRequest) Validate() error {
+	var errs ValidationErrors
+	if p.Amount.Minor <= 0 {
+		errs = append(errs, &ValidationError{Field: "amount", Message: "must be positive"})
+	}
+	if p.Currency == "" {
+		errs = append(errs, &ValidationError{Field: "currency", Message: "is required"})
+	} else {
+		errs = errs.add("", validateMarket("fee", "", p.Currency, nil))
+	}
+	if p.Card6 != "" && !isDigits(p.Card6, 6) {
+		errs = append(errs, &ValidationError{Field: "card6", Message: "must be 6 digits"})
+	}
+	return errs.err()
+}
+
 // GetFeeResponse is a type of rave's response to a get fee request
 type GetFeeResponse struct {
 	Data struct {
-		ChargeAmount string  `json:"charge_amount"`
-		Fee          float64 `json:"fee"`
-		Merchantfee  string  `json:"merchantfee"`
-		Ravefee      string  `json:"ravefee"`
+		ChargeAmount Money `json:"charge_amount"`
+		Fee          Money `json:"fee"`
+		Merchantfee  Money `json:"merchantfee"`
+		Ravefee      Money `json:"ravefee"`
 	} `json:"data"`
 	Message string `json:"message"`
 	Status  string `json:"status"`
@@ -28,14 +48,38 @@ type GetFeeResponse struct {
 // GetFee returns rave's fee response for the given get fee request
 // it returns any error that occures
 func GetFee(p *GetFeeRequest) (*GetFeeResponse, error) {
-	// TODO: add request params validation
+	return GetFeeContext(context.Background(), p)
+}
+
+// GetFeeContext is like GetFee but the request is bound to the given context
+func GetFeeContext(ctx context.Context, p *GetFeeRequest) (*GetFeeResponse, error) {
+	return defaultClient().getFee(ctx, feeURL, p)
+}
+
+// GetFee returns rave's fee response for the given get fee request
+// it returns any error that occures
+func (c *Client) GetFee(p *GetFeeRequest) (*GetFeeResponse, error) {
+	return c.GetFeeContext(context.Background(), p)
+}
+
+// GetFeeContext is like GetFee but the request is bound to the given context
+func (c *Client) GetFeeContext(ctx context.Context, p *GetFeeRequest) (*GetFeeResponse, error) {
+	return c.getFee(ctx, c.buildURL(getFeeURL), p)
+}
+
+func (c *Client) getFee(ctx context.Context, url string, p *GetFeeRequest) (*GetFeeResponse, error) {
+	if err := p.Validate(); err != nil {
+		return &GetFeeResponse{}, err
+	}
 	if p.PBFPubKey == "" {
-		p.PBFPubKey = PublicKey
+		p.PBFPubKey = c.PublicKey
 	}
 
 	resp := &GetFeeResponse{}
 
-	err := sendRequestAndParseResponse("POST", feeURL, p, resp)
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = GetFeeResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", url, p, resp)
+	}, nil)
 	return resp, err
-
 }
@@ -25,7 +25,7 @@ func TestGetFee(t *testing.T) {
 		{
 			name: "returns the GetFee response",
 			args: args{
-				p: &GetFeeRequest{},
+				p: &GetFeeRequest{Amount: NewMoney(100000, "NGN"), Currency: "NGN"},
 			},
 			want: &GetFeeResponse{
 				Status:  "success",
@@ -37,7 +37,7 @@ func TestGetFee(t *testing.T) {
 		{
 			name: "sets the PBFPubKey if empty",
 			args: args{
-				p: &GetFeeRequest{},
+				p: &GetFeeRequest{Amount: NewMoney(100000, "NGN"), Currency: "NGN"},
 			},
 			want: &GetFeeResponse{
 				Status:  "success",
@@ -49,7 +49,7 @@ func TestGetFee(t *testing.T) {
 		{
 			name: "doesn't override the PBFPubKey if present",
 			args: args{
-				p: &GetFeeRequest{PBFPubKey: "my-pub-key"},
+				p: &GetFeeRequest{Amount: NewMoney(100000, "NGN"), Currency: "NGN", PBFPubKey: "my-pub-key"},
 			},
 			want: &GetFeeResponse{
 				Status:  "success",
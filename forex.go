@@ -1,25 +1,48 @@
 package ravepay
 
+import "context"
+
 // FIXME: Done to enable testing
 var fxURL = buildURL(forexURL)
 
 // ForexParams type represents allowed params for querying rave's forex endpoint
 type ForexParams struct {
-	Amount              string `json:"amount"`
+	// Amount is converted to the destination currency if set
+	Amount              *Money `json:"amount,omitempty"`
 	OriginCurrency      string `json:"origin_currency"`
 	DestinationCurrency string `json:"destination_currency"`
 	SecKey              string `json:"SECKEY"`
 }
 
+// Validate checks the params have origin and destination currencies rave accepts
+// and a positive amount if one is set
+func (fxp *ForexParams) Validate() error {
+	var errs ValidationErrors
+	for _, c := range []struct{ field, code string }{
+		{"origin_currency", fxp.OriginCurrency},
+		{"destination_currency", fxp.DestinationCurrency},
+	} {
+		if c.code == "" {
+			errs = append(errs, &ValidationError{Field: c.field, Message: "is required"})
+		} else if _, ok := LookupCurrency(c.code); !ok {
+			errs = append(errs, &ValidationError{Field: c.field, Message: c.code + " isn't a currency rave accepts"})
+		}
+	}
+	if fxp.Amount != nil && fxp.Amount.Minor <= 0 {
+		errs = append(errs, &ValidationError{Field: "amount", Message: "must be positive"})
+	}
+	return errs.err()
+}
+
 // ForexResponse is raves response for forex rate request
 type ForexResponse struct {
 	Data struct {
-		ConvertedAmount     int    `json:"converted_amount"`
-		Destinationcurrency string `json:"destinationcurrency"`
-		Lastupdated         string `json:"lastupdated"`
-		OriginalAmount      string `json:"original_amount"`
-		Origincurrency      string `json:"origincurrency"`
-		Rate                int    `json:"rate"`
+		ConvertedAmount     Money   `json:"converted_amount"`
+		Destinationcurrency string  `json:"destinationcurrency"`
+		Lastupdated         string  `json:"lastupdated"`
+		OriginalAmount      Money   `json:"original_amount"`
+		Origincurrency      string  `json:"origincurrency"`
+		Rate                float64 `json:"rate"`
 	} `json:"data"`
 	Message string `json:"message"`
 	Status  string `json:"status"`
@@ -28,11 +51,37 @@ type ForexResponse struct {
 // ForexRate queries rave forex endpoint for the current rate of the currencies in the params
 // it returns the request response and any error that occurs
 func ForexRate(fxp *ForexParams) (*ForexResponse, error) {
+	return ForexRateContext(context.Background(), fxp)
+}
+
+// ForexRateContext is like ForexRate but the request is bound to the given context
+func ForexRateContext(ctx context.Context, fxp *ForexParams) (*ForexResponse, error) {
+	return defaultClient().forexRate(ctx, fxURL, fxp)
+}
+
+// ForexRate queries rave forex endpoint for the current rate of the currencies in the params
+// it returns the request response and any error that occurs
+func (c *Client) ForexRate(fxp *ForexParams) (*ForexResponse, error) {
+	return c.ForexRateContext(context.Background(), fxp)
+}
+
+// ForexRateContext is like ForexRate but the request is bound to the given context
+func (c *Client) ForexRateContext(ctx context.Context, fxp *ForexParams) (*ForexResponse, error) {
+	return c.forexRate(ctx, c.buildURL(forexURL), fxp)
+}
+
+func (c *Client) forexRate(ctx context.Context, url string, fxp *ForexParams) (*ForexResponse, error) {
+	if err := fxp.Validate(); err != nil {
+		return &ForexResponse{}, err
+	}
 	if fxp.SecKey == "" {
-		fxp.SecKey = SecretKey
+		fxp.SecKey = c.SecretKey
 	}
 
 	resp := &ForexResponse{}
-	err := sendRequestAndParseResponse("POST", fxURL, fxp, resp)
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = ForexResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", url, fxp, resp)
+	}, nil)
 	return resp, err
 }
@@ -25,7 +25,7 @@ func TestForexRate(t *testing.T) {
 		{
 			name: "returns the forex response with amount",
 			args: args{
-				fxp: &ForexParams{},
+				fxp: &ForexParams{OriginCurrency: "USD", DestinationCurrency: "NGN"},
 			},
 			respBody: forexRateWithAmountResponse,
 			want: &ForexResponse{
@@ -38,7 +38,7 @@ func TestForexRate(t *testing.T) {
 		{
 			name: "returns the forex response without amount",
 			args: args{
-				fxp: &ForexParams{},
+				fxp: &ForexParams{OriginCurrency: "USD", DestinationCurrency: "NGN"},
 			},
 			respBody: forexRateWithoutAmountResponse,
 			want: &ForexResponse{
@@ -51,7 +51,7 @@ func TestForexRate(t *testing.T) {
 		{
 			name: "sets the secret key if empty",
 			args: args{
-				fxp: &ForexParams{},
+				fxp: &ForexParams{OriginCurrency: "USD", DestinationCurrency: "NGN"},
 			},
 			respBody: forexRateWithAmountResponse,
 			want: &ForexResponse{
@@ -64,7 +64,7 @@ func TestForexRate(t *testing.T) {
 		{
 			name: "doesn't override the secret key if present",
 			args: args{
-				fxp: &ForexParams{SecKey: "my-sec-key"},
+				fxp: &ForexParams{OriginCurrency: "USD", DestinationCurrency: "NGN", SecKey: "my-sec-key"},
 			},
 			respBody: forexRateWithAmountResponse,
 			want: &ForexResponse{
@@ -74,6 +74,15 @@ func TestForexRate(t *testing.T) {
 			wantSecKey: "my-sec-key",
 			wantErr:    false,
 		},
+		{
+			name: "rejects params without currencies before sending the request",
+			args: args{
+				fxp: &ForexParams{SecKey: "my-sec-key"},
+			},
+			want:       &ForexResponse{},
+			wantSecKey: "my-sec-key",
+			wantErr:    true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
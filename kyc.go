@@ -0,0 +1,147 @@
+package ravepay
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ResolveAccountResponse is a type of rave response for resolving a bank account
+type ResolveAccountResponse struct {
+	Data struct {
+		Data struct {
+			AccountName     string `json:"accountname"`
+			AccountNumber   string `json:"accountnumber"`
+			Responsecode    string `json:"responsecode"`
+			Responsemessage string `json:"responsemessage"`
+			UniqueReference string `json:"uniquereference"`
+		} `json:"data"`
+		Status string `json:"status"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// AccountName returns the name of the resolved account's holder
+func (r *ResolveAccountResponse) AccountName() string {
+	return r.Data.Data.AccountName
+}
+
+// BVN is the identity registered to a bank verification number
+type BVN struct {
+	BVN              string `json:"bvn"`
+	FirstName        string `json:"first_name"`
+	MiddleName       string `json:"middle_name"`
+	LastName         string `json:"last_name"`
+	DateOfBirth      string `json:"date_of_birth"`
+	Gender           string `json:"gender"`
+	Email            string `json:"email"`
+	PhoneNumber      string `json:"phone_number"`
+	Nationality      string `json:"nationality"`
+	EnrollmentBank   string `json:"enrollment_bank"`
+	EnrollmentBranch string `json:"enrollment_branch"`
+	RegistrationDate string `json:"registration_date"`
+}
+
+// BVNResponse is a type of rave response for verifying a bvn
+type BVNResponse struct {
+	Data    BVN    `json:"data"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// ResolveAccount returns the holder of the account with the given number at the bank with the given code
+// It returns an APIError if rave can't resolve the account
+// https://developer.flutterwave.com/v2.0/reference#resolve-account
+func ResolveAccount(bankCode, accountNumber string) (*ResolveAccountResponse, error) {
+	return ResolveAccountContext(context.Background(), bankCode, accountNumber)
+}
+
+// ResolveAccountContext is like ResolveAccount but the request is bound to the given context
+func ResolveAccountContext(ctx context.Context, bankCode, accountNumber string) (*ResolveAccountResponse, error) {
+	return defaultClient().ResolveAccountContext(ctx, bankCode, accountNumber)
+}
+
+// ResolveAccount returns the holder of the account with the given number at the bank with the given code
+// It returns an APIError if rave can't resolve the account
+// https://developer.flutterwave.com/v2.0/reference#resolve-account
+func (c *Client) ResolveAccount(bankCode, accountNumber string) (*ResolveAccountResponse, error) {
+	return c.ResolveAccountContext(context.Background(), bankCode, accountNumber)
+}
+
+// ResolveAccountContext is like ResolveAccount but the request is bound to the given context
+func (c *Client) ResolveAccountContext(ctx context.Context, bankCode, accountNumber string) (*ResolveAccountResponse, error) {
+	payload := struct {
+		RecipientAccount string `json:"recipientaccount"`
+		DestBankCode     string `json:"destbankcode"`
+		PBFPubKey        string `json:"PBFPubKey"`
+	}{accountNumber, bankCode, c.PublicKey}
+
+	resp := &ResolveAccountResponse{}
+	endpoint := c.buildURL(resolveAccountURL)
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = ResolveAccountResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", endpoint, payload, resp)
+	}, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	// rave reports accounts it can't resolve in the nested response code
+	if resp.Data.Data.Responsecode != "00" {
+		return resp, &APIError{
+			HTTPStatus: 200,
+			Status:     resp.Data.Status,
+			Message:    resp.Data.Data.Responsemessage,
+			Method:     "POST",
+			Endpoint:   endpoint,
+		}
+	}
+	return resp, nil
+}
+
+// VerifyBVN returns the identity registered to the bank verification number
+// https://developer.flutterwave.com/v2.0/reference#verify-bvn
+func VerifyBVN(bvn string) (*BVNResponse, error) {
+	return VerifyBVNContext(context.Background(), bvn)
+}
+
+// VerifyBVNContext is like VerifyBVN but the request is bound to the given context
+func VerifyBVNContext(ctx context.Context, bvn string) (*BVNResponse, error) {
+	return defaultClient().VerifyBVNContext(ctx, bvn)
+}
+
+// VerifyBVN returns the identity registered to the bank verification number
+// https://developer.flutterwave.com/v2.0/reference#verify-bvn
+func (c *Client) VerifyBVN(bvn string) (*BVNResponse, error) {
+	return c.VerifyBVNContext(context.Background(), bvn)
+}
+
+// VerifyBVNContext is like VerifyBVN but the request is bound to the given context
+func (c *Client) VerifyBVNContext(ctx context.Context, bvn string) (*BVNResponse, error) {
+	resp := &BVNResponse{}
+	if !isDigits(bvn, 11) {
+		return resp, &ValidationError{Field: "bvn", Message: "must be 11 digits"}
+	}
+
+	query := url.Values{"seckey": {c.SecretKey}}
+	endpoint := c.buildURL(fmt.Sprintf(verifyBVNURL, bvn)) + "?" + query.Encode()
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = BVNResponse{}
+		return c.sendRequestAndParseResponse(ctx, "GET", endpoint, nil, resp)
+	}, nil)
+	return resp, err
+}
+
+// isDigits reports whether s is made of n digits
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package ravepay
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ResolveAccount(t *testing.T) {
+	handler := &transferTestServer{resp: resolvedAccountResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.ResolveAccount("044", "0690000034")
+	if err != nil {
+		t.Fatalf("Client.ResolveAccount() error = %v", err)
+	}
+	if handler.path != resolveAccountURL {
+		t.Errorf("request path = %s, want %s", handler.path, resolveAccountURL)
+	}
+	wantPayload := map[string]interface{}{"recipientaccount": "0690000034", "destbankcode": "044", "PBFPubKey": "pub-key"}
+	for k, v := range wantPayload {
+		if handler.payload[k] != v {
+			t.Errorf("request payload[%s] = %v, want %v", k, handler.payload[k], v)
+		}
+	}
+	if got.AccountName() != "Ade Bond" {
+		t.Errorf("Client.ResolveAccount().AccountName() = %s, want %s", got.AccountName(), "Ade Bond")
+	}
+
+	handler.resp = unresolvedAccountResponse
+	_, err = c.ResolveAccount("044", "0000000000")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "INVALID ACCOUNT" || apiErr.Endpoint != server.URL+resolveAccountURL {
+		t.Errorf("Client.ResolveAccount() error = %v, want APIError with message INVALID ACCOUNT", err)
+	}
+}
+
+func TestClient_VerifyBVN(t *testing.T) {
+	handler := &transferTestServer{resp: bvnResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+
+	tests := []struct {
+		name    string
+		bvn     string
+		wantErr error
+	}{
+		{name: "verifies the bvn", bvn: "12345678901"},
+		{name: "rejects a short bvn", bvn: "1234567890", wantErr: ErrValidation},
+		{name: "rejects a bvn with letters", bvn: "1234567890a", wantErr: ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler.path = ""
+			got, err := c.VerifyBVN(tt.bvn)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Client.VerifyBVN() error = %v, want %v", err, tt.wantErr)
+				}
+				if handler.path != "" {
+					t.Errorf("Client.VerifyBVN() made a request to %s", handler.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Client.VerifyBVN() error = %v", err)
+			}
+			if wantPath := fmt.Sprintf(verifyBVNURL, tt.bvn); handler.path != wantPath || handler.query["seckey"] != "sec-key" {
+				t.Errorf("request = %s %v, want %s", handler.path, handler.query, wantPath)
+			}
+			if got.Data.BVN != tt.bvn || got.Data.FirstName != "Wendy" || got.Data.DateOfBirth != "01-01-1905" {
+				t.Errorf("Client.VerifyBVN() = %+v", got.Data)
+			}
+		})
+	}
+
+	handler.resp = `{"status":"error","message":"BVN not found","data":null}`
+	if _, err := c.VerifyBVN("12345678901"); !errors.As(err, new(*APIError)) {
+		t.Errorf("Client.VerifyBVN() error = %v, want APIError", err)
+	}
+}
+
+var resolvedAccountResponse = `{"status":"success","message":"ACCOUNT RESOLVED","data":{"data":{"responsecode":"00","accountnumber":"0690000034","accountname":"Ade Bond","responsemessage":"Approved Or Completed Successfully","phonenumber":null,"uniquereference":"FLWT001014123","internalreference":null,"bankcode":"044"},"status":"success"}}`
+
+var unresolvedAccountResponse = `{"status":"success","message":"ACCOUNT RESOLVED","data":{"data":{"responsecode":"RR","accountnumber":"0000000000","accountname":null,"responsemessage":"INVALID ACCOUNT","phonenumber":null,"uniquereference":"FLWT001014124","internalreference":null,"bankcode":"044"},"status":"success"}}`
+
+var bvnResponse = `{"status":"success","message":"BVN-DETAILS","data":{"bvn":"12345678901","first_name":"Wendy","middle_name":"Chucky","last_name":"Rhoades","date_of_birth":"01-01-1905","phone_number":"08012345678","registration_date":"01-01-1921","enrollment_bank":"044","enrollment_branch":"Idejo","gender":"Female","email":null,"nationality":"Nigeria"}}`
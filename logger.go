@@ -0,0 +1,214 @@
+package ravepay
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// LogLevel is the severity of a log entry
+type LogLevel int
+
+// Log levels in increasing severity, debug entries include the requests and responses sent to rave
+const (
+	LogLevelDebug LogLevel = iota - 1
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// Logger is implemented by log backends the package logs to
+// Args are alternating keys and values like slog's, e.g "url", url, "err", err
+// Card numbers, cvvs, pins, otps, secret keys and passcodes are masked in the message and args before they reach the logger
+type Logger interface {
+	// Enabled reports whether entries of the level are logged
+	// it lets the package skip building and masking entries that would be dropped
+	Enabled(ctx context.Context, level LogLevel) bool
+	Log(ctx context.Context, level LogLevel, msg string, args ...interface{})
+}
+
+// NewSlogLogger returns a Logger that logs to the slog logger
+// The slog logger's handler decides which levels are logged
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) Enabled(ctx context.Context, level LogLevel) bool {
+	return s.l.Enabled(ctx, slogLevel(level))
+}
+
+func (s slogLogger) Log(ctx context.Context, level LogLevel, msg string, args ...interface{}) {
+	s.l.Log(ctx, slogLevel(level), msg, args...)
+}
+
+func slogLevel(level LogLevel) slog.Level {
+	return slog.Level(level * 4)
+}
+
+// NewStdLogger returns a Logger that logs entries of the given level and above to the standard library logger
+// Entries are written as the level, message and key=value args e.g ERROR request failed err="EOF"
+func NewStdLogger(l *log.Logger, level LogLevel) Logger {
+	return stdLogger{l: l, level: level}
+}
+
+type stdLogger struct {
+	l     *log.Logger
+	level LogLevel
+}
+
+func (s stdLogger) Enabled(ctx context.Context, level LogLevel) bool {
+	return level >= s.level
+}
+
+func (s stdLogger) Log(ctx context.Context, level LogLevel, msg string, args ...interface{}) {
+	var b strings.Builder
+	b.WriteString(level.String() + " " + msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			fmt.Fprintf(&b, " %v", args[i])
+			break
+		}
+		fmt.Fprintf(&b, " %v=%q", args[i], fmt.Sprint(args[i+1]))
+	}
+	s.l.Print(b.String())
+}
+
+var (
+	loggerMu sync.RWMutex
+	// packageLogger is used by the package level functions and clients without a logger
+	// it logs info and above to the standard library's default logger
+	packageLogger Logger = NewStdLogger(log.Default(), LogLevelInfo)
+)
+
+// SetLogger sets the logger used by the package level functions and clients created without WithLogger
+func SetLogger(l Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	packageLogger = l
+}
+
+func defaultLogger() Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	return packageLogger
+}
+
+// logTo masks the message and args and logs them to the logger if it's enabled for the level
+func logTo(ctx context.Context, l Logger, level LogLevel, msg string, args ...interface{}) {
+	if l == nil || !l.Enabled(ctx, level) {
+		return
+	}
+	masked := make([]interface{}, len(args))
+	for i, arg := range args {
+		masked[i] = redactValue(arg)
+	}
+	l.Log(ctx, level, Redact(msg), masked...)
+}
+
+// logf logs to the package logger, it is for code paths without a client like building charge payloads
+func logf(ctx context.Context, level LogLevel, msg string, args ...interface{}) {
+	logTo(ctx, defaultLogger(), level, msg, args...)
+}
+
+// log logs to the client's logger
+func (c *Client) log(ctx context.Context, level LogLevel, msg string, args ...interface{}) {
+	logTo(ctx, c.getLogger(), level, msg, args...)
+}
+
+func (c *Client) getLogger() Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return defaultLogger()
+}
+
+// redactedValue replaces the masked sensitive values
+const redactedValue = "[REDACTED]"
+
+var (
+	// sensitiveJSONFields matches json fields with card details, otps, secret keys or passcodes
+	sensitiveJSONFields = regexp.MustCompile(`(?i)"(cardno|card_no|cvv|pin|otp|seckey|secret_key|passcode)"\s*:\s*("(?:[^"\\]|\\.)*"|[0-9]+)`)
+	// sensitiveQueryParams matches query and form params with otps, pins, cvvs or secret keys
+	sensitiveQueryParams = regexp.MustCompile(`(?i)\b(seckey|cvv|pin|otp)=[^&\s"]*`)
+	// secretKeys matches rave secret keys wherever they appear
+	secretKeys = regexp.MustCompile(`FLWSECK(?:_TEST)?-[0-9A-Za-z]+(?:-X)?`)
+	// digitRuns are card number candidates, only runs passing the luhn check are masked
+	digitRuns = regexp.MustCompile(`\b[0-9]{13,19}\b`)
+)
+
+// Redact masks card numbers, cvvs, pins, otps, secret keys and account passcodes in the text
+// Card numbers keep their first 6 and last 4 digits e.g 543889******0229
+// It is applied to everything the package logs, and can be used on anything else logged about rave requests
+func Redact(s string) string {
+	s = sensitiveJSONFields.ReplaceAllString(s, `"$1":"`+redactedValue+`"`)
+	s = sensitiveQueryParams.ReplaceAllString(s, "$1="+redactedValue)
+	s = secretKeys.ReplaceAllString(s, "FLWSECK-"+redactedValue)
+	return digitRuns.ReplaceAllStringFunc(s, func(digits string) string {
+		if !luhnValid(digits) {
+			return digits
+		}
+		return maskPAN(digits)
+	})
+}
+
+// maskPAN keeps the first 6 and last 4 digits of the card number
+func maskPAN(pan string) string {
+	return pan[:6] + strings.Repeat("*", len(pan)-10) + pan[len(pan)-4:]
+}
+
+// redactPAN masks the card number, numbers too short to keep their bin and last 4 digits are masked entirely
+func redactPAN(pan string) string {
+	if len(pan) < 13 {
+		return redactSecret(pan)
+	}
+	return maskPAN(pan)
+}
+
+// redactSecret masks the secret, empty secrets are left empty so it's clear they weren't set
+func redactSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// goString formats the redacted value of a type in go syntax under the type's exported name
+// v is the value converted to a method-less copy of the type, so formatting it doesn't recurse into GoString
+func goString(name string, v interface{}) string {
+	s := fmt.Sprintf("%#v", v)
+	return "ravepay." + name + s[strings.Index(s, "{"):]
+}
+
+// redactValue masks the log arg, numbers and bools are logged as is
+// anything else is logged as its masked text so no card details slip through in a struct
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case bool, int, int64, float64, LogLevel:
+		return v
+	case []byte:
+		return Redact(string(v))
+	}
+	return Redact(fmt.Sprint(v))
+}
@@ -0,0 +1,111 @@
+package ravepay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "masks card details in json",
+			text: `{"cardno":"5438898014560229","cvv":"789","pin":3310,"expirymonth":"09"}`,
+			want: `{"cardno":"[REDACTED]","cvv":"[REDACTED]","pin":"[REDACTED]","expirymonth":"09"}`,
+		},
+		{
+			name: "masks otps, secret keys and passcodes in json",
+			text: `{"otp": "12345", "SECKEY":"sec-key", "passcode":"09101989","PBFPubKey":"pub-key"}`,
+			want: `{"otp":"[REDACTED]", "SECKEY":"[REDACTED]", "passcode":"[REDACTED]","PBFPubKey":"pub-key"}`,
+		},
+		{
+			name: "masks query params",
+			text: "GET http://rave.test/v2/kyc/bvn/12345678901?seckey=sec-key&otp=12345",
+			want: "GET http://rave.test/v2/kyc/bvn/12345678901?seckey=[REDACTED]&otp=[REDACTED]",
+		},
+		{
+			name: "masks secret keys anywhere",
+			text: "invalid key FLWSECK-e6db11d1f8a6208de8cb2f94e293450e-X",
+			want: "invalid key FLWSECK-[REDACTED]",
+		},
+		{
+			name: "keeps the bin and last 4 digits of card numbers",
+			text: "card 5438898014560229 declined",
+			want: "card 543889******0229 declined",
+		},
+		{
+			name: "keeps digits that aren't card numbers",
+			text: "account 0690000031 ref 5438898014560228",
+			want: "account 0690000031 ref 5438898014560228",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.text); got != tt.want {
+				t.Errorf("Redact() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0), LogLevelWarn)
+
+	logTo(context.Background(), l, LogLevelInfo, "dropped")
+	logTo(context.Background(), l, LogLevelError, "request failed", "url", "http://rave.test?seckey=sec-key", "status", 502, "err", errors.New("EOF"))
+
+	want := "ERROR request failed url=\"http://rave.test?seckey=[REDACTED]\" status=\"502\" err=\"EOF\"\n"
+	if buf.String() != want {
+		t.Errorf("stdLogger logged %q, want %q", buf.String(), want)
+	}
+}
+
+func TestClient_logsRequestsAtDebug(t *testing.T) {
+	handler := &testServer{resp: []byte(xRQSuccessfulVerificationResponse)}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := NewClient(WithKeys("pub-key", "FLWSECK-e6db11d1f8a6208de8cb2f94e293450e-X"), WithBaseURL(server.URL), WithLogger(NewSlogLogger(logger)))
+
+	tvc := &TxnVerificationChecklist{FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", Amount: NewMoney(30000, ""), TransactionCurrency: "NGN", VerificationURL: server.URL}
+	c.VerifyTransaction(tvc)
+
+	got := buf.String()
+	if !strings.Contains(got, "level=DEBUG msg=\"rave request\"") || !strings.Contains(got, "msg=\"rave response\"") {
+		t.Errorf("the client logged %s, want its request and response", got)
+	}
+	if strings.Contains(got, "e6db11d1f8a6208de8cb2f94e293450e") || !strings.Contains(got, redactedValue) {
+		t.Errorf("the client logged %s, want the secret key masked", got)
+	}
+
+	buf.Reset()
+	logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	NewClient(WithBaseURL(server.URL), WithLogger(NewSlogLogger(logger))).VerifyTransaction(tvc)
+	if buf.Len() != 0 {
+		t.Errorf("the client logged %s above debug level", buf.String())
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	defer SetLogger(defaultLogger())
+
+	var buf bytes.Buffer
+	SetLogger(NewStdLogger(log.New(&buf, "", 0), LogLevelInfo))
+
+	(&WebhookHandler{SecretHash: "hash"}).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", nil))
+	if !strings.HasPrefix(buf.String(), "WARN rejected webhook") {
+		t.Errorf("the package logger logged %q, want the rejected webhook", buf.String())
+	}
+}
@@ -2,8 +2,9 @@ package ravepay
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"log"
+	"io"
 	"net/http"
 )
 
@@ -19,42 +20,60 @@ func Int(v int) *int { return &v }
 // to store v and returns a pointer to it.
 func String(v string) *string { return &v }
 
-func sendRequestAndParseResponse(mtd, url string, payload, respObj interface{}) error {
-	resp, err := sendRequest(mtd, url, payload)
+// sendRequestAndParseResponse makes the request and decodes the response body into respObj
+// It returns an *APIError if the request fails in transport or the response reports a failure
+// in which case respObj is still populated with whatever rave returned
+func (c *Client) sendRequestAndParseResponse(ctx context.Context, mtd, url string, payload, respObj interface{}) error {
+	resp, err := c.sendRequest(ctx, mtd, url, payload)
 	if err != nil {
-		log.Println("Error occured while making request", err)
-		return err
+		c.log(ctx, LogLevelError, "error occured while making request", "method", mtd, "url", url, "err", err)
+		return &APIError{Method: mtd, Endpoint: redactURL(url), Err: redactTransportError(err)}
 	}
+	defer resp.Body.Close()
 
-	err = json.NewDecoder(resp.Body).Decode(respObj)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Println("Error occured while parsing response body", err)
+		c.log(ctx, LogLevelError, "error occured while reading response body", "method", mtd, "url", url, "err", err)
+		return &APIError{HTTPStatus: resp.StatusCode, Method: mtd, Endpoint: redactURL(url), Err: err}
+	}
+
+	c.log(ctx, LogLevelDebug, "rave response", "method", mtd, "url", url, "status", resp.StatusCode, "body", body)
+	apiErr := checkResponse(mtd, url, resp.StatusCode, body)
+
+	err = json.Unmarshal(body, respObj)
+	if apiErr != nil {
+		return apiErr
+	}
+	if err != nil {
+		c.log(ctx, LogLevelError, "error occured while parsing response body", "method", mtd, "url", url, "err", err)
 	}
 
 	return err
 }
 
-func sendRequest(mtd, url string, payload interface{}) (*http.Response, error) {
-	var req *http.Request
-	var err error
+func (c *Client) sendRequest(ctx context.Context, mtd, url string, payload interface{}) (*http.Response, error) {
+	var body io.Reader
 
 	if payload != nil {
-		body, err := json.Marshal(payload)
+		b, err := json.Marshal(payload)
 		if err != nil {
-			log.Println("Error marshalling request payload: ", err)
+			c.log(ctx, LogLevelError, "error marshalling request payload", "method", mtd, "url", url, "err", err)
 			return nil, err
 		}
-		req, err = http.NewRequest(mtd, url, bytes.NewBuffer(body))
-	} else {
-		req, err = http.NewRequest(mtd, url, nil)
+		c.log(ctx, LogLevelDebug, "rave request", "method", mtd, "url", url, "body", b)
+		body = bytes.NewBuffer(b)
+	}
+
+	if payload == nil {
+		c.log(ctx, LogLevelDebug, "rave request", "method", mtd, "url", url)
 	}
 
+	req, err := http.NewRequestWithContext(ctx, mtd, url, body)
 	if err != nil {
-		log.Println("Error occured while creating request", err)
+		c.log(ctx, LogLevelError, "error occured while creating request", "method", mtd, "url", url, "err", err)
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{}
-	return client.Do(req)
+	return c.httpClient.Do(req)
 }
@@ -1,8 +1,8 @@
 package ravepay
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 )
 
 // MobileMoneyGH is a type that encapsulates rave's ghana mobile money description
@@ -47,7 +47,25 @@ func (gh *MobileMoneyGH) BuildChargeRequestPayload(cReq *ChargeRequest) []byte {
 	}{gh, cReq}
 	b, err := json.Marshal(payload)
 	if err != nil {
-		log.Println("couldn't marshal payload: ", err)
+		logf(context.Background(), LogLevelError, "couldn't marshal payload", "err", err)
 	}
 	return b
 }
+
+// mobileMoneyGHMarkets are the countries and currencies ghana mobile money charges accept
+var mobileMoneyGHMarkets = []market{{"GH", "GHS"}}
+
+// Validate checks the mobile money charge has a network and is in GHS from GH
+func (gh *MobileMoneyGH) Validate() error {
+	var errs ValidationErrors
+	if gh.Network == "" {
+		errs = append(errs, &ValidationError{Field: "network", Message: "is required"})
+	}
+	errs = errs.add("", validateMarket("ghana mobile money", gh.Country, gh.Currency, mobileMoneyGHMarkets))
+	return errs.err()
+}
+
+// validateCharge checks the charge amount is valid in GHS
+func (gh *MobileMoneyGH) validateCharge(cReq *ChargeRequest) error {
+	return validateAmountPrecision(cReq.Amount, gh.Currency)
+}
@@ -0,0 +1,196 @@
+package ravepay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// minorUnitsPerUnit is the number of minor units in a unit of currencies with two decimal places, e.g kobo for NGN and cents for USD
+// Amounts without a currency, or with one that isn't registered, are in these hundredths
+const minorUnitsPerUnit = 100
+
+// minorUnitsPer returns the number of minor units in a unit of the currency by its exponent e.g 1 for UGX
+func minorUnitsPer(currency string) int64 {
+	c, ok := LookupCurrency(currency)
+	if !ok {
+		return minorUnitsPerUnit
+	}
+	per := int64(1)
+	for i := 0; i < c.Exponent; i++ {
+		per *= 10
+	}
+	return per
+}
+
+// Money is an amount of a currency in its minor units e.g 150050 NGN is ₦1,500.50
+// and 1000 UGX is USh1,000 as UGX has no minor unit, amounts without a currency are in hundredths
+// As Minor's unit depends on the currency, amounts are compared by their value rather than their Minor
+// In rave's json the currency is a separate field, so Money is encoded as just the decimal amount e.g 1500.5
+// and the Currency of decoded amounts is empty unless it's set before decoding
+// Decoding is lenient about rave's variants of amounts: numbers, floats, quoted numbers and null
+type Money struct {
+	Minor    int64
+	Currency string
+}
+
+// NewMoney returns the amount in minor units of the currency
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: currency}
+}
+
+// ParseMoney returns the decimal amount of the currency e.g "1500.50"
+// It fails for amounts with more decimal places than the currency has e.g "100.5" UGX, rather than rounding them
+func ParseMoney(amount, currency string) (Money, error) {
+	r, err := parseAmount(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	per := minorUnitsPer(currency)
+	r.Mul(r, big.NewRat(per, 1))
+	if !r.IsInt() {
+		decimals := len(strconv.FormatInt(per, 10)) - 1
+		return Money{}, fmt.Errorf("ravepay: amount %q has more than the %d decimal places of %s", amount, decimals, currencyOrHundredths(currency))
+	}
+	minor, err := roundMinorUnits(r, amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+func currencyOrHundredths(currency string) string {
+	if currency == "" {
+		return "amounts without a currency"
+	}
+	return currency
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Minor == 0
+}
+
+// Float64 returns the amount in units of the currency
+// it is only for display and arithmetic that tolerates rounding, compare Minor for exact values
+func (m Money) Float64() float64 {
+	return float64(m.Minor) / float64(minorUnitsPer(m.Currency))
+}
+
+// compare compares the values of the amounts: -1 if m is less than other, 0 if they're equal and +1 if m is more
+// Minor is in the currency's minor units, or hundredths without a currency, so amounts are only compared with it
+// An amount without a currency is compared as an amount of the other's currency, amounts of different currencies fail
+func (m Money) compare(other Money) (int, error) {
+	if m.Currency != "" && other.Currency != "" && !strings.EqualFold(m.Currency, other.Currency) {
+		return 0, fmt.Errorf("ravepay: can't compare %s %s with %s %s", m, m.Currency, other, other.Currency)
+	}
+	return m.units().Cmp(other.units()), nil
+}
+
+// units returns the exact amount in units of the currency
+func (m Money) units() *big.Rat {
+	return big.NewRat(m.Minor, minorUnitsPer(m.Currency))
+}
+
+// String returns the decimal amount without trailing zeros e.g 1500.5, it doesn't include the currency
+func (m Money) String() string {
+	minor := m.Minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	per := minorUnitsPer(m.Currency)
+	units, cents := minor/per, minor%per
+	if cents == 0 {
+		return sign + strconv.FormatInt(units, 10)
+	}
+	decimals := len(strconv.FormatInt(per, 10)) - 1
+	return strings.TrimRight(fmt.Sprintf("%s%d.%0*d", sign, units, decimals, cents), "0")
+}
+
+// MarshalJSON encodes the amount as a json number in units of the currency
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a json number, a quoted number, an empty string or null into the amount
+// in minor units of the amount's currency if it's set, otherwise in hundredths
+func (m *Money) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		m.Minor = 0
+		return nil
+	}
+
+	raw := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return err
+		}
+		raw = strings.TrimSpace(raw)
+	}
+	if raw == "" {
+		m.Minor = 0
+		return nil
+	}
+
+	minor, err := parseMinorUnits(raw, minorUnitsPer(m.Currency))
+	if err != nil {
+		return err
+	}
+	m.Minor = minor
+	return nil
+}
+
+// parseMinorUnits converts the decimal amount to minor units, per unit, without going through a float
+// Decimal places beyond the minor units are rounded half away from zero, it's for rave's amounts e.g 0.037500000000000006
+func parseMinorUnits(amount string, per int64) (int64, error) {
+	r, err := parseAmount(amount)
+	if err != nil {
+		return 0, err
+	}
+	return roundMinorUnits(r.Mul(r, big.NewRat(per, 1)), amount)
+}
+
+// parseAmount parses the decimal amount exactly
+func parseAmount(amount string) (*big.Rat, error) {
+	// big.Rat also parses fractions and hex, neither of which are amounts
+	if strings.Trim(amount, "+-0123456789.eE") != "" {
+		return nil, fmt.Errorf("ravepay: invalid amount %q", amount)
+	}
+	// and huge exponents are slow to expand
+	if i := strings.IndexAny(amount, "eE"); i >= 0 {
+		if exp, err := strconv.Atoi(amount[i+1:]); err != nil || exp > 18 || exp < -18 {
+			return nil, fmt.Errorf("ravepay: invalid amount %q", amount)
+		}
+	}
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, fmt.Errorf("ravepay: invalid amount %q", amount)
+	}
+	return r, nil
+}
+
+// roundMinorUnits rounds the amount in minor units half away from zero
+func roundMinorUnits(r *big.Rat, amount string) (int64, error) {
+	num, denom := new(big.Int).Set(r.Num()), r.Denom()
+	quo, rem := new(big.Int).QuoRem(num, denom, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(denom) >= 0 {
+		quo.Add(quo, big.NewInt(int64(num.Sign())))
+	}
+	if !quo.IsInt64() {
+		return 0, fmt.Errorf("ravepay: amount %q is out of range", amount)
+	}
+	return quo.Int64(), nil
+}
+
+// minorUnits converts a float amount in units of a currency to hundredths
+func minorUnits(amount float64) int64 {
+	minor, _ := parseMinorUnits(strconv.FormatFloat(amount, 'f', -1, 64), minorUnitsPerUnit)
+	return minor
+}
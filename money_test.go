@@ -0,0 +1,204 @@
+package ravepay
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    int64
+		wantErr bool
+	}{
+		{amount: "1500", want: 150000},
+		{amount: "1500.5", want: 150050},
+		{amount: "1500.05", want: 150005},
+		{amount: "0.1", want: 10},
+		{amount: "-20.25", want: -2025},
+		{amount: "0.375", wantErr: true},
+		{amount: "10.005", wantErr: true},
+		{amount: "1.50000", want: 150},
+		{amount: "1e3", want: 100000},
+		{amount: "", wantErr: true},
+		{amount: "1,500", wantErr: true},
+		{amount: "1/2", wantErr: true},
+		{amount: "0x10", wantErr: true},
+		{amount: "1e1000000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			got, err := ParseMoney(tt.amount, "NGN")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMoney() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got.Minor != tt.want || got.Currency != "NGN") {
+				t.Errorf("ParseMoney() = %+v, want %d NGN", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		minor int64
+		want  string
+	}{
+		{minor: 150000, want: "1500"},
+		{minor: 150050, want: "1500.5"},
+		{minor: 150005, want: "1500.05"},
+		{minor: 5, want: "0.05"},
+		{minor: -2025, want: "-20.25"},
+		{minor: 0, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := NewMoney(tt.minor, "NGN").String(); got != tt.want {
+				t.Errorf("Money.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    int64
+		wantErr bool
+	}{
+		{json: `300`, want: 30000},
+		{json: `300.5`, want: 30050},
+		{json: `0.037500000000000006`, want: 4},
+		{json: `"20020"`, want: 2002000},
+		{json: `" 15.75 "`, want: 1575},
+		{json: `""`, want: 0},
+		{json: `null`, want: 0},
+		{json: `"N/A"`, wantErr: true},
+		{json: `true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var got struct {
+				Amount Money `json:"amount"`
+			}
+			err := json.Unmarshal([]byte(`{"amount":`+tt.json+`}`), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Money.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Amount.Minor != tt.want {
+				t.Errorf("Money.UnmarshalJSON() = %d, want %d", got.Amount.Minor, tt.want)
+			}
+		})
+	}
+
+	b, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{NewMoney(150050, "NGN")})
+	if err != nil || string(b) != `{"amount":1500.5}` {
+		t.Errorf("Money.MarshalJSON() = %s, %v, want %s", b, err, `{"amount":1500.5}`)
+	}
+}
+
+func TestMoney_zeroExponentCurrencies(t *testing.T) {
+	for _, currency := range []string{"UGX", "RWF", "XAF", "XOF"} {
+		t.Run(currency, func(t *testing.T) {
+			m := NewMoney(1000, currency)
+			if got := m.String(); got != "1000" {
+				t.Errorf("Money.String() = %s, want 1000", got)
+			}
+			if got := m.Float64(); got != 1000 {
+				t.Errorf("Money.Float64() = %v, want 1000", got)
+			}
+			b, err := json.Marshal(m)
+			if err != nil || string(b) != "1000" {
+				t.Errorf("Money.MarshalJSON() = %s, %v, want 1000", b, err)
+			}
+
+			got := Money{Currency: currency}
+			if err := json.Unmarshal([]byte(`"1000"`), &got); err != nil || got != m {
+				t.Errorf("Money.UnmarshalJSON() = %+v, %v, want %+v", got, err, m)
+			}
+			if got, err := ParseMoney("1000", currency); err != nil || got != m {
+				t.Errorf("ParseMoney() = %+v, %v, want %+v", got, err, m)
+			}
+			if got, err := ParseMoney("1000.5", currency); err == nil {
+				t.Errorf("ParseMoney() = %+v, want an error for a fraction of %s", got, currency)
+			}
+			if c, err := NewMoney(100000, "").compare(m); err != nil || c != 0 {
+				t.Errorf("Money.compare() = %d, %v, want hundredths equal to %+v", c, err, m)
+			}
+		})
+	}
+
+	m := NewMoney(150050, "NGN")
+	if b, _ := json.Marshal(m); string(b) != "1500.5" {
+		t.Errorf("Money.MarshalJSON() = %s, want 1500.5", b)
+	}
+}
+
+func TestMoney_compare(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Money
+		other   Money
+		want    int
+		wantErr bool
+	}{
+		{name: "equal amounts", m: NewMoney(150050, "NGN"), other: NewMoney(150050, "NGN"), want: 0},
+		{name: "a smaller amount", m: NewMoney(150049, "NGN"), other: NewMoney(150050, "NGN"), want: -1},
+		{name: "a bigger amount", m: NewMoney(150051, "NGN"), other: NewMoney(150050, "NGN"), want: 1},
+		{name: "hundredths with the same value", m: NewMoney(100000, ""), other: NewMoney(1000, "UGX"), want: 0},
+		{name: "hundredths with a fraction of a zero decimal currency", m: NewMoney(99950, ""), other: NewMoney(1000, "UGX"), want: -1},
+		{name: "currencies regardless of case", m: NewMoney(1000, "ugx"), other: NewMoney(1000, "UGX"), want: 0},
+		{name: "different currencies", m: NewMoney(1000, "UGX"), other: NewMoney(1000, "RWF"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.compare(tt.other)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Money.compare() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Money.compare() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_verifyAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     Money
+		got      Money
+		currency string
+		wantErr  bool
+	}{
+		{name: "accepts an equal amount", want: NewMoney(150050, "NGN"), got: NewMoney(150050, ""), currency: "NGN"},
+		{name: "accepts a greater amount", want: NewMoney(150050, "NGN"), got: NewMoney(150051, ""), currency: "NGN"},
+		{name: "rejects an amount short by a minor unit", want: NewMoney(150050, "NGN"), got: NewMoney(150049, ""), currency: "NGN", wantErr: true},
+		{name: "rejects an amount in another currency", want: NewMoney(150050, "NGN"), got: NewMoney(150050, ""), currency: "USD", wantErr: true},
+		{name: "rejects a transaction without a currency", want: NewMoney(150050, "NGN"), got: NewMoney(150050, ""), wantErr: true},
+		{name: "ignores the currency if the expected amount has none", want: NewMoney(150050, ""), got: NewMoney(150050, ""), currency: "USD"},
+		{name: "compares zero decimal currencies in their units", want: NewMoney(1000, "UGX"), got: NewMoney(100000, ""), currency: "UGX"},
+		{name: "rejects a zero decimal amount short by a unit", want: NewMoney(1000, "UGX"), got: NewMoney(99900, ""), currency: "UGX", wantErr: true},
+		{name: "rejects a zero decimal amount short by a fraction", want: NewMoney(1000, "UGX"), got: NewMoney(99950, ""), currency: "UGX", wantErr: true},
+		{name: "compares hundredths with zero decimal currencies", want: NewMoney(100000, ""), got: NewMoney(1000, "UGX"), currency: "UGX"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyAmount(tt.want, tt.got, tt.currency); (err != nil) != tt.wantErr {
+				t.Errorf("verifyAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_verifyExactAmount(t *testing.T) {
+	// a fraction of a zero decimal currency isn't rounded away
+	if err := verifyExactAmount(NewMoney(1000, "UGX"), NewMoney(100040, ""), "UGX"); err == nil {
+		t.Errorf("verifyExactAmount() accepted 1000.4 for exactly 1000 UGX")
+	}
+	if err := verifyExactAmount(NewMoney(1000, "UGX"), NewMoney(100000, ""), "UGX"); err != nil {
+		t.Errorf("verifyExactAmount() error = %v, want nil", err)
+	}
+}
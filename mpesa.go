@@ -1,8 +1,8 @@
 package ravepay
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 )
 
 // Mpesa is a type that encapsulates rave's mpesa description
@@ -20,7 +20,7 @@ type Mpesa struct {
 // MpesaPaymentInfo is the information necessary for completing mpesa payment
 type MpesaPaymentInfo struct {
 	AccountNumber  string
-	Amount         int
+	Amount         Money
 	BusinessNumber string
 }
 
@@ -53,11 +53,24 @@ func (m *Mpesa) BuildChargeRequestPayload(cReq *ChargeRequest) []byte {
 	}{m, cReq}
 	b, err := json.Marshal(payload)
 	if err != nil {
-		log.Println("couldn't marshal payload: ", err)
+		logf(context.Background(), LogLevelError, "couldn't marshal payload", "err", err)
 	}
 	return b
 }
 
+// mpesaMarkets are the countries and currencies mpesa charges accept
+var mpesaMarkets = []market{{"KE", "KES"}}
+
+// Validate checks the mpesa charge is in KES from KE
+func (m *Mpesa) Validate() error {
+	return ValidationErrors{}.add("", validateMarket("mpesa", m.Country, m.Currency, mpesaMarkets)).err()
+}
+
+// validateCharge checks the charge amount is valid in KES
+func (m *Mpesa) validateCharge(cReq *ChargeRequest) error {
+	return validateAmountPrecision(cReq.Amount, m.Currency)
+}
+
 // MpesaPaymentInstruction parses the given charge response
 // and returns the payment info: business number and account number for completing the payment
 func MpesaPaymentInstruction(cr *ChargeResponse) *MpesaPaymentInfo {
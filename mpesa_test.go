@@ -19,14 +19,14 @@ func TestMpesaPaymentInstruction(t *testing.T) {
 			args: args{
 				cr: &ChargeResponse{
 					Data: chargeResponseData{
-						Amount:         900,
+						Amount:         NewMoney(90000, ""),
 						OrderRef:       "some-ref",
 						BusinessNumber: "some-biz-num",
 					},
 				},
 			},
 			want: &MpesaPaymentInfo{
-				Amount:         900,
+				Amount:         NewMoney(90000, ""),
 				AccountNumber:  "some-ref",
 				BusinessNumber: "some-biz-num",
 			},
@@ -3,7 +3,7 @@ package ravepay
 // Payment is a type that encapsulates rave's concept of payment
 // together with PaymentVerfication it contains required implementations for interacting with the payment APIs
 type Payment struct {
-	Amount            int    `json:"amount"`
+	Amount            Money  `json:"amount"`
 	Country           string `json:"country"`
 	Currency          string `json:"currency"`
 	CustomDescription string `json:"custom_description"`
@@ -18,20 +18,32 @@ type Payment struct {
 }
 
 // NewTxnVerificationChecklist returns a new paymentVerificationChecklist object with the given params
-func NewTxnVerificationChecklist(amount int, flwRef, currency string) *TxnVerificationChecklist {
+func NewTxnVerificationChecklist(amount Money, flwRef, currency string) *TxnVerificationChecklist {
+	return defaultClient().NewTxnVerificationChecklist(amount, flwRef, currency)
+}
+
+// NewTxnVerificationChecklist returns a new paymentVerificationChecklist object with the given params
+// It is set up with the client's secret key and verification endpoint
+func (c *Client) NewTxnVerificationChecklist(amount Money, flwRef, currency string) *TxnVerificationChecklist {
 	return &TxnVerificationChecklist{
 		Amount:              amount,
 		Done:                false,
 		FlwRef:              flwRef,
 		TransactionCurrency: currency,
-		SECKEY:              SecretKey,
-		VerificationURL:     buildURL(txnVerificationURL),
+		SECKEY:              c.SecretKey,
+		VerificationURL:     c.buildURL(txnVerificationURL),
 	}
 }
 
 // NewXRQTxnVerificationChecklist returns a new paymentVerificationChecklist object with the given params
 // It sets up the checklist to use the rave's xrequery transaction verification endpoint
-func NewXRQTxnVerificationChecklist(amount int, flwRef, txRef, currency string) *TxnVerificationChecklist {
+func NewXRQTxnVerificationChecklist(amount Money, flwRef, txRef, currency string) *TxnVerificationChecklist {
+	return defaultClient().NewXRQTxnVerificationChecklist(amount, flwRef, txRef, currency)
+}
+
+// NewXRQTxnVerificationChecklist returns a new paymentVerificationChecklist object with the given params
+// It sets up the checklist to use the client's xrequery transaction verification endpoint
+func (c *Client) NewXRQTxnVerificationChecklist(amount Money, flwRef, txRef, currency string) *TxnVerificationChecklist {
 	return &TxnVerificationChecklist{
 		Amount:              amount,
 		Done:                false,
@@ -42,7 +54,7 @@ func NewXRQTxnVerificationChecklist(amount int, flwRef, txRef, currency string)
 		TransactionCurrency: currency,
 		TxRef:               txRef,
 		Txref:               txRef,
-		SECKEY:              SecretKey,
-		VerificationURL:     buildURL(txnVerificationRequeryURL),
+		SECKEY:              c.SecretKey,
+		VerificationURL:     c.buildURL(txnVerificationRequeryURL),
 	}
 }
@@ -0,0 +1,230 @@
+package ravepay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// PaymentPlan is a type of rave payment plan resource
+// Charges made with a payment plan enrol the customer on a subscription to the plan
+type PaymentPlan struct {
+	Amount      Money  `json:"amount"`
+	Currency    string `json:"currency"`
+	DateCreated string `json:"date_created"`
+	Duration    int    `json:"duration"`
+	ID          int    `json:"id"`
+	Interval    string `json:"interval"`
+	Name        string `json:"name"`
+	PlanToken   string `json:"plan_token"`
+	Status      string `json:"status"`
+}
+
+// UnmarshalJSON decodes a payment plan
+// charge and verification responses reference the payment plan by its id alone
+// so a bare (or quoted) id decodes into a payment plan with just the id set
+func (p *PaymentPlan) UnmarshalJSON(b []byte) error {
+	var id json.Number
+	if err := json.Unmarshal(b, &id); err == nil {
+		n, err := strconv.Atoi(id.String())
+		if err != nil {
+			return fmt.Errorf("PaymentPlanDecodingFailed: invalid payment plan id %s", id)
+		}
+		*p = PaymentPlan{ID: n}
+		return nil
+	}
+
+	type paymentPlan PaymentPlan
+	return json.Unmarshal(b, (*paymentPlan)(p))
+}
+
+// PaymentPlanRequest holds the information necessary for creating a payment plan
+// https://developer.flutterwave.com/v2.0/reference#create-payment-plan
+type PaymentPlanRequest struct {
+	// Amount is charged on each interval, the customer is charged the amount of the charge they are enrolled with if nil
+	Amount *Money `json:"amount,omitempty"`
+	// Duration is the number of times the customer is charged, 0 charges the customer indefinitely
+	Duration int `json:"duration,omitempty"`
+	// Interval is how often the customer is charged e.g daily, weekly, monthly, quarterly, yearly
+	Interval string `json:"interval"`
+	Name     string `json:"name"`
+	SECKEY   string `json:"seckey"`
+}
+
+// PaymentPlanResponse is a type of rave response for a single payment plan
+type PaymentPlanResponse struct {
+	Data    PaymentPlan `json:"data"`
+	Message string      `json:"message"`
+	Status  string      `json:"status"`
+}
+
+// PaymentPlanListResponse is a type of rave response for querying payment plans
+type PaymentPlanListResponse struct {
+	Data struct {
+		PageInfo     PageInfo      `json:"page_info"`
+		PaymentPlans []PaymentPlan `json:"paymentplans"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// ErrPaymentPlanNotFound is returned by GetPaymentPlan when no payment plan matches the id
+var ErrPaymentPlanNotFound = errors.New("ravepay: no payment plan matches the id")
+
+// CreatePaymentPlan makes a request to create the payment plan
+// It sets the secret key on the request if empty
+func CreatePaymentPlan(p *PaymentPlanRequest) (*PaymentPlanResponse, error) {
+	return CreatePaymentPlanContext(context.Background(), p)
+}
+
+// CreatePaymentPlanContext is like CreatePaymentPlan but the request is bound to the given context
+func CreatePaymentPlanContext(ctx context.Context, p *PaymentPlanRequest) (*PaymentPlanResponse, error) {
+	return defaultClient().CreatePaymentPlanContext(ctx, p)
+}
+
+// CreatePaymentPlan makes a request to create the payment plan
+// It sets the secret key on the request if empty
+func (c *Client) CreatePaymentPlan(p *PaymentPlanRequest) (*PaymentPlanResponse, error) {
+	return c.CreatePaymentPlanContext(context.Background(), p)
+}
+
+// CreatePaymentPlanContext is like CreatePaymentPlan but the request is bound to the given context
+func (c *Client) CreatePaymentPlanContext(ctx context.Context, p *PaymentPlanRequest) (*PaymentPlanResponse, error) {
+	if p.SECKEY == "" {
+		p.SECKEY = c.SecretKey
+	}
+
+	resp := &PaymentPlanResponse{}
+	err := c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(createPaymentPlanURL), p, resp)
+	return resp, err
+}
+
+// ListPaymentPlans returns the given page of the merchant's payment plans, pages start from 1
+func ListPaymentPlans(page int) (*PaymentPlanListResponse, error) {
+	return ListPaymentPlansContext(context.Background(), page)
+}
+
+// ListPaymentPlansContext is like ListPaymentPlans but the request is bound to the given context
+func ListPaymentPlansContext(ctx context.Context, page int) (*PaymentPlanListResponse, error) {
+	return defaultClient().ListPaymentPlansContext(ctx, page)
+}
+
+// ListPaymentPlans returns the given page of the merchant's payment plans, pages start from 1
+func (c *Client) ListPaymentPlans(page int) (*PaymentPlanListResponse, error) {
+	return c.ListPaymentPlansContext(context.Background(), page)
+}
+
+// ListPaymentPlansContext is like ListPaymentPlans but the request is bound to the given context
+func (c *Client) ListPaymentPlansContext(ctx context.Context, page int) (*PaymentPlanListResponse, error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	return c.queryPaymentPlans(ctx, query)
+}
+
+// GetPaymentPlan returns the payment plan with the given id
+func GetPaymentPlan(id int) (*PaymentPlanResponse, error) {
+	return GetPaymentPlanContext(context.Background(), id)
+}
+
+// GetPaymentPlanContext is like GetPaymentPlan but the request is bound to the given context
+func GetPaymentPlanContext(ctx context.Context, id int) (*PaymentPlanResponse, error) {
+	return defaultClient().GetPaymentPlanContext(ctx, id)
+}
+
+// GetPaymentPlan returns the payment plan with the given id
+func (c *Client) GetPaymentPlan(id int) (*PaymentPlanResponse, error) {
+	return c.GetPaymentPlanContext(context.Background(), id)
+}
+
+// GetPaymentPlanContext is like GetPaymentPlan but the request is bound to the given context
+func (c *Client) GetPaymentPlanContext(ctx context.Context, id int) (*PaymentPlanResponse, error) {
+	list, err := c.queryPaymentPlans(ctx, url.Values{"id": {strconv.Itoa(id)}})
+	resp := &PaymentPlanResponse{Status: list.Status, Message: list.Message}
+	if err != nil {
+		return resp, err
+	}
+
+	if len(list.Data.PaymentPlans) == 0 {
+		return resp, ErrPaymentPlanNotFound
+	}
+	resp.Data = list.Data.PaymentPlans[0]
+	return resp, nil
+}
+
+func (c *Client) queryPaymentPlans(ctx context.Context, query url.Values) (*PaymentPlanListResponse, error) {
+	query.Set("seckey", c.SecretKey)
+
+	resp := &PaymentPlanListResponse{}
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = PaymentPlanListResponse{}
+		return c.sendRequestAndParseResponse(ctx, "GET", c.buildURL(queryPaymentPlansURL)+"?"+query.Encode(), nil, resp)
+	}, nil)
+	return resp, err
+}
+
+// EditPaymentPlan makes a request to update the name and or status of the payment plan with the given id
+// empty values are left unchanged
+func EditPaymentPlan(id int, name, status string) (*PaymentPlanResponse, error) {
+	return EditPaymentPlanContext(context.Background(), id, name, status)
+}
+
+// EditPaymentPlanContext is like EditPaymentPlan but the request is bound to the given context
+func EditPaymentPlanContext(ctx context.Context, id int, name, status string) (*PaymentPlanResponse, error) {
+	return defaultClient().EditPaymentPlanContext(ctx, id, name, status)
+}
+
+// EditPaymentPlan makes a request to update the name and or status of the payment plan with the given id
+// empty values are left unchanged
+func (c *Client) EditPaymentPlan(id int, name, status string) (*PaymentPlanResponse, error) {
+	return c.EditPaymentPlanContext(context.Background(), id, name, status)
+}
+
+// EditPaymentPlanContext is like EditPaymentPlan but the request is bound to the given context
+func (c *Client) EditPaymentPlanContext(ctx context.Context, id int, name, status string) (*PaymentPlanResponse, error) {
+	payload := struct {
+		Name   string `json:"name,omitempty"`
+		Status string `json:"status,omitempty"`
+		SECKEY string `json:"seckey"`
+	}{name, status, c.SecretKey}
+
+	return c.updatePaymentPlan(ctx, fmt.Sprintf(editPaymentPlanURL, id), payload)
+}
+
+// CancelPaymentPlan makes a request to cancel the payment plan with the given id
+func CancelPaymentPlan(id int) (*PaymentPlanResponse, error) {
+	return CancelPaymentPlanContext(context.Background(), id)
+}
+
+// CancelPaymentPlanContext is like CancelPaymentPlan but the request is bound to the given context
+func CancelPaymentPlanContext(ctx context.Context, id int) (*PaymentPlanResponse, error) {
+	return defaultClient().CancelPaymentPlanContext(ctx, id)
+}
+
+// CancelPaymentPlan makes a request to cancel the payment plan with the given id
+func (c *Client) CancelPaymentPlan(id int) (*PaymentPlanResponse, error) {
+	return c.CancelPaymentPlanContext(context.Background(), id)
+}
+
+// CancelPaymentPlanContext is like CancelPaymentPlan but the request is bound to the given context
+func (c *Client) CancelPaymentPlanContext(ctx context.Context, id int) (*PaymentPlanResponse, error) {
+	payload := struct {
+		SECKEY string `json:"seckey"`
+	}{c.SecretKey}
+
+	return c.updatePaymentPlan(ctx, fmt.Sprintf(cancelPaymentPlanURL, id), payload)
+}
+
+// updatePaymentPlan sends the payment plan update, updates set the plan's state so they are safe to retry
+func (c *Client) updatePaymentPlan(ctx context.Context, path string, payload interface{}) (*PaymentPlanResponse, error) {
+	resp := &PaymentPlanResponse{}
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = PaymentPlanResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(path), payload, resp)
+	}, nil)
+	return resp, err
+}
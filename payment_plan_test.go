@@ -0,0 +1,146 @@
+package ravepay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPaymentPlan_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *PaymentPlan
+		wantErr bool
+	}{
+		{name: "decodes null as no payment plan", data: `null`, want: nil},
+		{name: "decodes a bare id", data: `1018`, want: &PaymentPlan{ID: 1018}},
+		{name: "decodes a quoted id", data: `"1018"`, want: &PaymentPlan{ID: 1018}},
+		{
+			name: "decodes a payment plan object",
+			data: `{"id":1018,"name":"pro","amount":1000,"interval":"monthly","duration":12,"status":"active","currency":"NGN","plan_token":"rpp_7156ac29","date_created":"2018-06-20T09:41:47.000Z"}`,
+			want: &PaymentPlan{ID: 1018, Name: "pro", Amount: NewMoney(100000, ""), Interval: "monthly", Duration: 12, Status: "active", Currency: "NGN", PlanToken: "rpp_7156ac29", DateCreated: "2018-06-20T09:41:47.000Z"},
+		},
+		{name: "rejects a non numeric id", data: `"pro"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *PaymentPlan
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PaymentPlan.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PaymentPlan.UnmarshalJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_CreatePaymentPlan(t *testing.T) {
+	handler := &transferTestServer{resp: paymentPlanResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.CreatePaymentPlan(&PaymentPlanRequest{Name: "pro", Amount: &Money{Minor: 100000}, Interval: "monthly", Duration: 12})
+	if err != nil {
+		t.Fatalf("Client.CreatePaymentPlan() error = %v", err)
+	}
+
+	want := map[string]interface{}{"name": "pro", "amount": float64(1000), "interval": "monthly", "duration": float64(12), "seckey": "sec-key"}
+	if handler.path != createPaymentPlanURL || !reflect.DeepEqual(handler.payload, want) {
+		t.Errorf("request = %s %v, want %s %v", handler.path, handler.payload, createPaymentPlanURL, want)
+	}
+	if got.Data.ID != 1018 || got.Data.PlanToken != "rpp_7156ac29" {
+		t.Errorf("Client.CreatePaymentPlan() = %+v", got.Data)
+	}
+}
+
+func TestClient_ListPaymentPlans(t *testing.T) {
+	handler := &transferTestServer{resp: paymentPlanListResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.ListPaymentPlans(2)
+	if err != nil {
+		t.Fatalf("Client.ListPaymentPlans() error = %v", err)
+	}
+	if handler.path != queryPaymentPlansURL || handler.query["page"] != "2" || handler.query["seckey"] != "sec-key" {
+		t.Errorf("request = %s %v", handler.path, handler.query)
+	}
+	if len(got.Data.PaymentPlans) != 1 || got.Data.PageInfo.TotalPages != 2 {
+		t.Errorf("Client.ListPaymentPlans() = %+v", got.Data)
+	}
+}
+
+func TestClient_GetPaymentPlan(t *testing.T) {
+	handler := &transferTestServer{resp: paymentPlanListResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.GetPaymentPlan(1018)
+	if err != nil {
+		t.Fatalf("Client.GetPaymentPlan() error = %v", err)
+	}
+	if handler.query["id"] != "1018" {
+		t.Errorf("request query = %v", handler.query)
+	}
+	if got.Data.ID != 1018 || got.Data.Name != "pro" {
+		t.Errorf("Client.GetPaymentPlan() = %+v", got.Data)
+	}
+
+	handler.resp = emptyPaymentPlanListResponse
+	if _, err := c.GetPaymentPlan(1); !errors.Is(err, ErrPaymentPlanNotFound) {
+		t.Errorf("Client.GetPaymentPlan() error = %v, want %v", err, ErrPaymentPlanNotFound)
+	}
+}
+
+func TestClient_EditPaymentPlan(t *testing.T) {
+	handler := &transferTestServer{resp: paymentPlanResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+
+	tests := []struct {
+		name        string
+		call        func() (*PaymentPlanResponse, error)
+		wantPath    string
+		wantPayload map[string]interface{}
+	}{
+		{
+			name:        "edits the payment plan",
+			call:        func() (*PaymentPlanResponse, error) { return c.EditPaymentPlan(1018, "pro plus", "") },
+			wantPath:    fmt.Sprintf(editPaymentPlanURL, 1018),
+			wantPayload: map[string]interface{}{"name": "pro plus", "seckey": "sec-key"},
+		},
+		{
+			name:        "cancels the payment plan",
+			call:        func() (*PaymentPlanResponse, error) { return c.CancelPaymentPlan(1018) },
+			wantPath:    fmt.Sprintf(cancelPaymentPlanURL, 1018),
+			wantPayload: map[string]interface{}{"seckey": "sec-key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.call(); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if handler.path != tt.wantPath || !reflect.DeepEqual(handler.payload, tt.wantPayload) {
+				t.Errorf("request = %s %v, want %s %v", handler.path, handler.payload, tt.wantPath, tt.wantPayload)
+			}
+		})
+	}
+}
+
+var paymentPlanResponse = `{"status":"success","message":"CREATED-PAYMENTPLAN","data":{"id":1018,"name":"pro","amount":1000,"interval":"monthly","duration":12,"status":"active","currency":"NGN","plan_token":"rpp_7156ac29","date_created":"2018-06-20T09:41:47.000Z"}}`
+
+var paymentPlanListResponse = `{"status":"success","message":"QUERIED-PAYMENTPLANS","data":{"page_info":{"total":11,"current_page":2,"total_pages":2},"paymentplans":[{"id":1018,"name":"pro","amount":1000,"interval":"monthly","duration":12,"status":"active","currency":"NGN","plan_token":"rpp_7156ac29","date_created":"2018-06-20T09:41:47.000Z"}]}}`
+
+var emptyPaymentPlanListResponse = `{"status":"success","message":"QUERIED-PAYMENTPLANS","data":{"page_info":{"total":0,"current_page":0,"total_pages":0},"paymentplans":[]}}`
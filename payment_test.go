@@ -7,7 +7,7 @@ import (
 
 func TestNewTxnVerificationChecklist(t *testing.T) {
 	type args struct {
-		amount   int
+		amount   Money
 		flwRef   string
 		currency string
 		secKey   string
@@ -20,13 +20,13 @@ func TestNewTxnVerificationChecklist(t *testing.T) {
 		{
 			name: "returns a new txn verification checklist",
 			args: args{
-				amount:   1000,
+				amount:   NewMoney(100000, ""),
 				flwRef:   "some-flw-ref",
 				currency: "NGN",
 				secKey:   "some-sec-key",
 			},
 			want: &TxnVerificationChecklist{
-				Amount:              1000,
+				Amount:              NewMoney(100000, ""),
 				FlwRef:              "some-flw-ref",
 				TransactionCurrency: "NGN",
 				SECKEY:              "some-sec-key",
@@ -46,7 +46,7 @@ func TestNewTxnVerificationChecklist(t *testing.T) {
 
 func TestNewXRQTxnVerificationChecklist(t *testing.T) {
 	type args struct {
-		amount   int
+		amount   Money
 		flwRef   string
 		txRef    string
 		currency string
@@ -60,13 +60,13 @@ func TestNewXRQTxnVerificationChecklist(t *testing.T) {
 		{
 			name: "returns a new txn verification checklist",
 			args: args{
-				amount:   1000,
+				amount:   NewMoney(100000, ""),
 				flwRef:   "some-flw-ref",
 				currency: "NGN",
 				secKey:   "some-sec-key",
 			},
 			want: &TxnVerificationChecklist{
-				Amount:              1000,
+				Amount:              NewMoney(100000, ""),
 				Flwref:              "some-flw-ref",
 				FlwRef:              "some-flw-ref",
 				TransactionCurrency: "NGN",
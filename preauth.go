@@ -0,0 +1,222 @@
+package ravepay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PreAuthState is the state of a preauthorized card payment
+type PreAuthState string
+
+// The states of a preauth
+// a preauth starts out new, is authorized by the charge and ends when it is captured, voided, refunded or expires
+const (
+	PreAuthStateNew        PreAuthState = "new"
+	PreAuthStateAuthorized PreAuthState = "authorized"
+	PreAuthStateCaptured   PreAuthState = "captured"
+	PreAuthStateVoided     PreAuthState = "voided"
+	PreAuthStateRefunded   PreAuthState = "refunded"
+	PreAuthStateExpired    PreAuthState = "expired"
+)
+
+// DefaultPreAuthExpiry is how long issuers hold preauthorized funds before releasing them
+const DefaultPreAuthExpiry = 7 * 24 * time.Hour
+
+// ErrInvalidPreAuthTransition is returned when a preauth action isn't valid in the preauth's state
+// e.g capturing a voided preauth
+var ErrInvalidPreAuthTransition = errors.New("ravepay: invalid preauth transition")
+
+// PreAuth is a preauthorized card payment
+// The card is charged with charge_type preauth to hold the amount, which is later captured in full or in part, or voided
+// a captured preauth can be refunded
+// Actions that aren't valid in the preauth's state are rejected with ErrInvalidPreAuthTransition without making a request
+// Its state and exported fields can be persisted to resume the preauth later with ResumePreAuth
+type PreAuth struct {
+	Request *ChargeRequest
+	Card    *Card
+
+	// FlwRef is the reference of the authorized charge
+	FlwRef string
+	// Amount is the authorized amount, CapturedAmount is the captured part of it
+	Amount         Money
+	CapturedAmount Money
+	// AuthorizedAt is when the charge was authorized, and Expiry how long after it the preauth expires
+	AuthorizedAt time.Time
+	Expiry       time.Duration
+
+	state  PreAuthState
+	client *Client
+	now    func() time.Time
+}
+
+// NewPreAuth returns a preauth for the charge request on the card
+func NewPreAuth(cr *ChargeRequest, card *Card) *PreAuth {
+	return defaultClient().NewPreAuth(cr, card)
+}
+
+// NewPreAuth returns a preauth for the charge request on the card using the client
+func (c *Client) NewPreAuth(cr *ChargeRequest, card *Card) *PreAuth {
+	return &PreAuth{
+		Request: cr,
+		Card:    card,
+		Expiry:  DefaultPreAuthExpiry,
+		state:   PreAuthStateNew,
+		client:  c,
+	}
+}
+
+// ResumePreAuth returns the preauth in the given state with the given details
+// it's for continuing a preauth authorized in an earlier process e.g capturing it
+func ResumePreAuth(state PreAuthState, flwRef string, amount Money, authorizedAt time.Time) *PreAuth {
+	return defaultClient().ResumePreAuth(state, flwRef, amount, authorizedAt)
+}
+
+// ResumePreAuth returns the preauth in the given state with the given details using the client
+// it's for continuing a preauth authorized in an earlier process e.g capturing it
+func (c *Client) ResumePreAuth(state PreAuthState, flwRef string, amount Money, authorizedAt time.Time) *PreAuth {
+	return &PreAuth{
+		FlwRef:       flwRef,
+		Amount:       amount,
+		AuthorizedAt: authorizedAt,
+		Expiry:       DefaultPreAuthExpiry,
+		state:        state,
+		client:       c,
+	}
+}
+
+// State returns the state of the preauth
+// an authorized preauth is expired once its expiry has passed since it was authorized
+func (p *PreAuth) State() PreAuthState {
+	if p.state == "" {
+		p.state = PreAuthStateNew
+	}
+	if p.state == PreAuthStateAuthorized && p.Expiry > 0 && p.clock().Sub(p.AuthorizedAt) > p.Expiry {
+		return PreAuthStateExpired
+	}
+	return p.state
+}
+
+// Authorize charges the card with charge_type preauth to hold the request's amount
+func (p *PreAuth) Authorize() (*ChargeResponse, error) {
+	return p.AuthorizeContext(context.Background())
+}
+
+// AuthorizeContext is like Authorize but the request is bound to the given context
+func (p *PreAuth) AuthorizeContext(ctx context.Context) (*ChargeResponse, error) {
+	if err := p.expect("authorize", PreAuthStateNew); err != nil {
+		return &ChargeResponse{}, err
+	}
+
+	p.Request.ChargeType = "preauth"
+	resp, err := p.getClient().ChargeContext(ctx, p.Request, p.Card)
+	if err != nil {
+		return resp, err
+	}
+	if resp.Data.ChargeResponseCode != "00" {
+		return resp, fmt.Errorf("PreAuthNotAuthorized: charge response code %s: %s", resp.Data.ChargeResponseCode, resp.Data.ChargeResponseMessage)
+	}
+
+	p.FlwRef = resp.Data.FlwRef
+	p.Amount = p.Request.Amount
+	p.AuthorizedAt = p.clock()
+	p.state = PreAuthStateAuthorized
+	return resp, nil
+}
+
+// Capture claims the amount of the authorized payment, the full amount is captured if amount is 0
+// The rest of a partially captured amount is released to the card holder
+func (p *PreAuth) Capture(amount Money) (*ChargeResponse, error) {
+	return p.CaptureContext(context.Background(), amount)
+}
+
+// CaptureContext is like Capture but the request is bound to the given context
+func (p *PreAuth) CaptureContext(ctx context.Context, amount Money) (*ChargeResponse, error) {
+	if err := p.expect("capture", PreAuthStateAuthorized); err != nil {
+		return &ChargeResponse{}, err
+	}
+	cmp, err := amount.compare(p.Amount)
+	if err != nil {
+		return &ChargeResponse{}, &ValidationError{Field: "amount", Message: fmt.Sprintf("must be in the authorized amount's currency %s", p.Amount.Currency)}
+	}
+	if amount.Minor < 0 || cmp > 0 {
+		return &ChargeResponse{}, &ValidationError{Field: "amount", Message: fmt.Sprintf("must be between 0 and the authorized amount of %s", p.Amount)}
+	}
+
+	c := p.getClient()
+	resp, err := c.capturePreAuthPayment(ctx, c.buildURL(capturePreAuthPaymentURL), p.FlwRef, amount)
+	if err != nil {
+		return resp, err
+	}
+
+	if amount.IsZero() {
+		amount = p.Amount
+	}
+	p.CapturedAmount = amount
+	p.state = PreAuthStateCaptured
+	return resp, nil
+}
+
+// Void releases the authorized payment to the card holder
+func (p *PreAuth) Void() (*PreAuthResponse, error) {
+	return p.VoidContext(context.Background())
+}
+
+// VoidContext is like Void but the request is bound to the given context
+func (p *PreAuth) VoidContext(ctx context.Context) (*PreAuthResponse, error) {
+	if err := p.expect("void", PreAuthStateAuthorized); err != nil {
+		return &PreAuthResponse{}, err
+	}
+
+	c := p.getClient()
+	resp, err := c.refundOrVoidPreAuthPayment(ctx, c.buildURL(voidOrRefundPreAuthURL), "void", p.FlwRef)
+	if err != nil {
+		return resp, err
+	}
+
+	p.state = PreAuthStateVoided
+	return resp, nil
+}
+
+// Refund refunds the captured payment to the card holder
+func (p *PreAuth) Refund() (*PreAuthResponse, error) {
+	return p.RefundContext(context.Background())
+}
+
+// RefundContext is like Refund but the request is bound to the given context
+func (p *PreAuth) RefundContext(ctx context.Context) (*PreAuthResponse, error) {
+	if err := p.expect("refund", PreAuthStateCaptured); err != nil {
+		return &PreAuthResponse{}, err
+	}
+
+	c := p.getClient()
+	resp, err := c.refundOrVoidPreAuthPayment(ctx, c.buildURL(voidOrRefundPreAuthURL), "refund", p.FlwRef)
+	if err != nil {
+		return resp, err
+	}
+
+	p.state = PreAuthStateRefunded
+	return resp, nil
+}
+
+func (p *PreAuth) expect(action string, state PreAuthState) error {
+	if current := p.State(); current != state {
+		return fmt.Errorf("%w: can't %s a %s preauth", ErrInvalidPreAuthTransition, action, current)
+	}
+	return nil
+}
+
+func (p *PreAuth) getClient() *Client {
+	if p.client == nil {
+		p.client = defaultClient()
+	}
+	return p.client
+}
+
+func (p *PreAuth) clock() time.Time {
+	if p.now == nil {
+		return time.Now()
+	}
+	return p.now()
+}
@@ -0,0 +1,133 @@
+package ravepay
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPreAuth_lifecycle(t *testing.T) {
+	handler := &transferTestServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys(PublicKey, SecretKey), WithBaseURL(server.URL))
+	cr := &ChargeRequest{Amount: NewMoney(2000, ""), Email: "tester@flutter.co", TxRef: "MC-1508990174050"}
+	p := c.NewPreAuth(cr, &Card{CardNo: "5840406187553286", Expirymonth: "09", Expiryyear: testExpiryYear, Cvv: "812", ChargeCardURL: server.URL})
+
+	if _, err := p.Capture(Money{}); !errors.Is(err, ErrInvalidPreAuthTransition) {
+		t.Errorf("PreAuth.Capture() before authorizing error = %v, want %v", err, ErrInvalidPreAuthTransition)
+	}
+
+	handler.resp = successfulPreAuthPaymentCaptureResponse
+	if _, err := p.Authorize(); err != nil {
+		t.Fatalf("PreAuth.Authorize() error = %v", err)
+	}
+	if p.State() != PreAuthStateAuthorized || cr.ChargeType != "preauth" || p.FlwRef != "FLW-MOCK-839c1abc23b6a4bbb9da807d54c5bbda" || p.Amount != NewMoney(2000, "") {
+		t.Errorf("PreAuth.Authorize() = %+v, charge type %s", p, cr.ChargeType)
+	}
+
+	if _, err := p.Refund(); !errors.Is(err, ErrInvalidPreAuthTransition) {
+		t.Errorf("PreAuth.Refund() before capturing error = %v, want %v", err, ErrInvalidPreAuthTransition)
+	}
+	if _, err := p.Capture(NewMoney(2500, "")); !errors.Is(err, ErrValidation) {
+		t.Errorf("PreAuth.Capture() above the authorized amount error = %v, want a validation error", err)
+	}
+
+	if _, err := p.Capture(NewMoney(1500, "")); err != nil {
+		t.Fatalf("PreAuth.Capture() error = %v", err)
+	}
+	if handler.path != capturePreAuthPaymentURL || handler.payload["amount"] != float64(15) || handler.payload["flwRef"] != p.FlwRef {
+		t.Errorf("capture request = %s %v", handler.path, handler.payload)
+	}
+	if p.State() != PreAuthStateCaptured || p.CapturedAmount != NewMoney(1500, "") {
+		t.Errorf("PreAuth.Capture() = %+v", p)
+	}
+
+	if _, err := p.Void(); !errors.Is(err, ErrInvalidPreAuthTransition) {
+		t.Errorf("PreAuth.Void() after capturing error = %v, want %v", err, ErrInvalidPreAuthTransition)
+	}
+
+	handler.resp = successfulPreAuthPaymentRefundResponse
+	if _, err := p.Refund(); err != nil {
+		t.Fatalf("PreAuth.Refund() error = %v", err)
+	}
+	if handler.path != voidOrRefundPreAuthURL || handler.payload["action"] != "refund" || p.State() != PreAuthStateRefunded {
+		t.Errorf("refund request = %s %v, state %s", handler.path, handler.payload, p.State())
+	}
+}
+
+func TestPreAuth_Capture_amounts(t *testing.T) {
+	handler := &transferTestServer{resp: successfulPreAuthPaymentCaptureResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	c := NewClient(WithKeys(PublicKey, SecretKey), WithBaseURL(server.URL))
+
+	tests := []struct {
+		name    string
+		amount  Money
+		wantErr bool
+	}{
+		{name: "captures the full amount in the currency", amount: NewMoney(1000, "UGX")},
+		{name: "captures the full amount in hundredths", amount: NewMoney(100000, "")},
+		{name: "captures part of the amount", amount: NewMoney(500, "ugx")},
+		{name: "rejects more than the authorized amount", amount: NewMoney(1001, "UGX"), wantErr: true},
+		{name: "rejects a fraction more than the authorized amount in hundredths", amount: NewMoney(100001, ""), wantErr: true},
+		{name: "rejects an amount in another currency", amount: NewMoney(1000, "RWF"), wantErr: true},
+		{name: "rejects negative amounts", amount: NewMoney(-1, "UGX"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := c.ResumePreAuth(PreAuthStateAuthorized, "FLW-MOCK-839c1abc23b6a4bbb9da807d54c5bbda", NewMoney(1000, "UGX"), time.Now())
+			_, err := p.Capture(tt.amount)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) || p.State() != PreAuthStateAuthorized {
+					t.Errorf("PreAuth.Capture() error = %v in state %s, want a validation error", err, p.State())
+				}
+				return
+			}
+			if err != nil || p.State() != PreAuthStateCaptured {
+				t.Errorf("PreAuth.Capture() error = %v in state %s, want it captured", err, p.State())
+			}
+		})
+	}
+}
+
+func TestPreAuth_Void(t *testing.T) {
+	handler := &transferTestServer{resp: successfulPreAuthPaymentRefundResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	p := c.ResumePreAuth(PreAuthStateAuthorized, "FLW-MOCK-839c1abc23b6a4bbb9da807d54c5bbda", NewMoney(2000, ""), time.Now())
+
+	if _, err := p.Void(); err != nil {
+		t.Fatalf("PreAuth.Void() error = %v", err)
+	}
+	if handler.payload["action"] != "void" || handler.payload["ref"] != p.FlwRef || p.State() != PreAuthStateVoided {
+		t.Errorf("void request = %v, state %s", handler.payload, p.State())
+	}
+
+	if _, err := p.Capture(Money{}); !errors.Is(err, ErrInvalidPreAuthTransition) {
+		t.Errorf("PreAuth.Capture() after voiding error = %v, want %v", err, ErrInvalidPreAuthTransition)
+	}
+}
+
+func TestPreAuth_State_expires(t *testing.T) {
+	authorizedAt := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	p := NewClient().ResumePreAuth(PreAuthStateAuthorized, "FLW-MOCK-839c1abc23b6a4bbb9da807d54c5bbda", NewMoney(2000, ""), authorizedAt)
+
+	p.now = func() time.Time { return authorizedAt.Add(DefaultPreAuthExpiry - time.Hour) }
+	if p.State() != PreAuthStateAuthorized {
+		t.Errorf("PreAuth.State() = %s, want %s before the expiry", p.State(), PreAuthStateAuthorized)
+	}
+
+	p.now = func() time.Time { return authorizedAt.Add(DefaultPreAuthExpiry + time.Hour) }
+	if p.State() != PreAuthStateExpired {
+		t.Errorf("PreAuth.State() = %s, want %s after the expiry", p.State(), PreAuthStateExpired)
+	}
+	if _, err := p.Capture(Money{}); !errors.Is(err, ErrInvalidPreAuthTransition) {
+		t.Errorf("PreAuth.Capture() after the expiry error = %v, want %v", err, ErrInvalidPreAuthTransition)
+	}
+}
@@ -1,5 +1,10 @@
 package ravepay
 
+import (
+	"context"
+	"strings"
+)
+
 // FIXME: Done to enable testing
 var (
 	capturePreAuthURL      = buildURL(capturePreAuthPaymentURL)
@@ -10,13 +15,73 @@ var (
 // It takes the flwRef as param and returns the capture response and any error that occures
 // https://flutterwavedevelopers.readme.io/v2.0/reference#capture
 func CapturePreAuthPayment(ref string) (*ChargeResponse, error) {
+	return CapturePreAuthPaymentContext(context.Background(), ref)
+}
+
+// CapturePreAuthPaymentContext is like CapturePreAuthPayment but the request is bound to the given context
+func CapturePreAuthPaymentContext(ctx context.Context, ref string) (*ChargeResponse, error) {
+	return defaultClient().capturePreAuthPayment(ctx, capturePreAuthURL, ref, Money{})
+}
+
+// CapturePreAuthPayment makes request to rave's capture endpoint to claim preauth payments
+// It takes the flwRef as param and returns the capture response and any error that occures
+// https://flutterwavedevelopers.readme.io/v2.0/reference#capture
+func (c *Client) CapturePreAuthPayment(ref string) (*ChargeResponse, error) {
+	return c.CapturePreAuthPaymentContext(context.Background(), ref)
+}
+
+// CapturePreAuthPaymentContext is like CapturePreAuthPayment but the request is bound to the given context
+func (c *Client) CapturePreAuthPaymentContext(ctx context.Context, ref string) (*ChargeResponse, error) {
+	return c.capturePreAuthPayment(ctx, c.buildURL(capturePreAuthPaymentURL), ref, Money{})
+}
+
+// CapturePreAuthPaymentAmount is like CapturePreAuthPayment but it captures only the given amount of the preauth payment
+// The rest of the authorized amount is released to the card holder, the full amount is captured if amount is 0
+func CapturePreAuthPaymentAmount(ref string, amount Money) (*ChargeResponse, error) {
+	return CapturePreAuthPaymentAmountContext(context.Background(), ref, amount)
+}
+
+// CapturePreAuthPaymentAmountContext is like CapturePreAuthPaymentAmount but the request is bound to the given context
+func CapturePreAuthPaymentAmountContext(ctx context.Context, ref string, amount Money) (*ChargeResponse, error) {
+	return defaultClient().CapturePreAuthPaymentAmountContext(ctx, ref, amount)
+}
+
+// CapturePreAuthPaymentAmount is like CapturePreAuthPayment but it captures only the given amount of the preauth payment
+// The rest of the authorized amount is released to the card holder, the full amount is captured if amount is 0
+func (c *Client) CapturePreAuthPaymentAmount(ref string, amount Money) (*ChargeResponse, error) {
+	return c.CapturePreAuthPaymentAmountContext(context.Background(), ref, amount)
+}
+
+// CapturePreAuthPaymentAmountContext is like CapturePreAuthPaymentAmount but the request is bound to the given context
+func (c *Client) CapturePreAuthPaymentAmountContext(ctx context.Context, ref string, amount Money) (*ChargeResponse, error) {
+	if amount.Minor < 0 {
+		return &ChargeResponse{}, &ValidationError{Field: "amount", Message: "must not be negative"}
+	}
+	return c.capturePreAuthPayment(ctx, c.buildURL(capturePreAuthPaymentURL), ref, amount)
+}
+
+// capturePreAuthPayment captures the amount of the preauth payment, the full amount is captured if amount is 0
+func (c *Client) capturePreAuthPayment(ctx context.Context, url, ref string, amount Money) (*ChargeResponse, error) {
 	resp := &ChargeResponse{}
 	payload := struct {
 		SECKEY string `json:"SECKEY"`
 		FlwRef string `json:"flwRef"`
-	}{SecretKey, ref}
+		Amount *Money `json:"amount,omitempty"`
+	}{SECKEY: c.SecretKey, FlwRef: ref}
+	if !amount.IsZero() {
+		payload.Amount = &amount
+	}
 
-	err := sendRequestAndParseResponse("POST", capturePreAuthURL, payload, resp)
+	// the capture is only re-sent if rave shows the transaction still pending capture
+	resend := func(ctx context.Context) bool {
+		lookup, err := c.lookupTransaction(ctx, ref, "")
+		return err == nil && strings.HasPrefix(lookup.Data.Status, "pending")
+	}
+
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = ChargeResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", url, payload, resp)
+	}, resend)
 
 	return resp, err
 }
@@ -47,16 +112,25 @@ type PreAuthResponse struct {
 // It returns the response and any error that occurs
 // https://flutterwavedevelopers.readme.io/v2.0/reference#refund-or-void
 func RefundPreAuthPayment(ref string) (*PreAuthResponse, error) {
-	resp := &PreAuthResponse{}
-	payload := struct {
-		Action string `json:"action"`
-		FlwRef string `json:"ref"`
-		SECKEY string `json:"SECKEY"`
-	}{"refund", SecretKey, ref}
+	return RefundPreAuthPaymentContext(context.Background(), ref)
+}
 
-	err := sendRequestAndParseResponse("POST", voidorRefundPreAuthURL, payload, resp)
+// RefundPreAuthPaymentContext is like RefundPreAuthPayment but the request is bound to the given context
+func RefundPreAuthPaymentContext(ctx context.Context, ref string) (*PreAuthResponse, error) {
+	return defaultClient().refundOrVoidPreAuthPayment(ctx, voidorRefundPreAuthURL, "refund", ref)
+}
 
-	return resp, err
+// RefundPreAuthPayment fullfiles the raves preauth feature by refunding the preauthorized paymemnt
+// It takes the flwRef and makes a request to refund the txn
+// It returns the response and any error that occurs
+// https://flutterwavedevelopers.readme.io/v2.0/reference#refund-or-void
+func (c *Client) RefundPreAuthPayment(ref string) (*PreAuthResponse, error) {
+	return c.RefundPreAuthPaymentContext(context.Background(), ref)
+}
+
+// RefundPreAuthPaymentContext is like RefundPreAuthPayment but the request is bound to the given context
+func (c *Client) RefundPreAuthPaymentContext(ctx context.Context, ref string) (*PreAuthResponse, error) {
+	return c.refundOrVoidPreAuthPayment(ctx, c.buildURL(voidOrRefundPreAuthURL), "refund", ref)
 }
 
 // VoidPreAuthPayment fullfiles the raves preauth feature by voiding the preauthorized paymemnt
@@ -64,14 +138,40 @@ func RefundPreAuthPayment(ref string) (*PreAuthResponse, error) {
 // It returns the response and any error that occurs
 // https://flutterwavedevelopers.readme.io/v2.0/reference#refund-or-void
 func VoidPreAuthPayment(ref string) (*PreAuthResponse, error) {
+	return VoidPreAuthPaymentContext(context.Background(), ref)
+}
+
+// VoidPreAuthPaymentContext is like VoidPreAuthPayment but the request is bound to the given context
+func VoidPreAuthPaymentContext(ctx context.Context, ref string) (*PreAuthResponse, error) {
+	return defaultClient().refundOrVoidPreAuthPayment(ctx, voidorRefundPreAuthURL, "void", ref)
+}
+
+// VoidPreAuthPayment fullfiles the raves preauth feature by voiding the preauthorized paymemnt
+// It takes the flwRef and makes a request to void the txn
+// It returns the response and any error that occurs
+// https://flutterwavedevelopers.readme.io/v2.0/reference#refund-or-void
+func (c *Client) VoidPreAuthPayment(ref string) (*PreAuthResponse, error) {
+	return c.VoidPreAuthPaymentContext(context.Background(), ref)
+}
+
+// VoidPreAuthPaymentContext is like VoidPreAuthPayment but the request is bound to the given context
+func (c *Client) VoidPreAuthPaymentContext(ctx context.Context, ref string) (*PreAuthResponse, error) {
+	return c.refundOrVoidPreAuthPayment(ctx, c.buildURL(voidOrRefundPreAuthURL), "void", ref)
+}
+
+func (c *Client) refundOrVoidPreAuthPayment(ctx context.Context, url, action, ref string) (*PreAuthResponse, error) {
 	resp := &PreAuthResponse{}
 	payload := struct {
 		Action string `json:"action"`
 		FlwRef string `json:"ref"`
 		SECKEY string `json:"SECKEY"`
-	}{"void", SecretKey, ref}
+	}{
+		Action: action,
+		FlwRef: ref,
+		SECKEY: c.SecretKey,
+	}
 
-	err := sendRequestAndParseResponse("POST", voidorRefundPreAuthURL, payload, resp)
+	err := c.sendRequestAndParseResponse(ctx, "POST", url, payload, resp)
 
 	return resp, err
 }
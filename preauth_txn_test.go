@@ -1,6 +1,8 @@
 package ravepay
 
 import (
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
@@ -32,8 +34,8 @@ func TestCapturePreAuthPayment(t *testing.T) {
 				Message: "Capture complete",
 				Data: chargeResponseData{
 					AccountID:     48,
-					Amount:        20,
-					Appfee:        0.25,
+					Amount:        NewMoney(2000, ""),
+					Appfee:        NewMoney(25, ""),
 					AuthModelUsed: "NOAUTH",
 					Authurl:       "N/A",
 					// ChargedAmount:         20.25,
@@ -89,6 +91,46 @@ func TestCapturePreAuthPayment(t *testing.T) {
 	}
 }
 
+func TestClient_CapturePreAuthPaymentAmount(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload = map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Write([]byte(successfulPreAuthPaymentCaptureResponse))
+	}))
+	defer server.Close()
+	c := NewClient(WithBaseURL(server.URL))
+
+	tests := []struct {
+		name       string
+		amount     Money
+		wantAmount interface{}
+		wantErr    bool
+	}{
+		{name: "captures the amount", amount: NewMoney(150050, "NGN"), wantAmount: 1500.5},
+		{name: "captures the full amount if the amount is 0", amount: Money{}, wantAmount: nil},
+		{name: "rejects negative amounts before sending them", amount: NewMoney(-100, "NGN"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload = nil
+			_, err := c.CapturePreAuthPaymentAmount("FLW-MOCK-839c1abc23b6a4bbb9da807d54c5bbda", tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.CapturePreAuthPaymentAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if payload != nil {
+					t.Errorf("Client.CapturePreAuthPaymentAmount() sent %v, want no request", payload)
+				}
+				return
+			}
+			if payload["flwRef"] != "FLW-MOCK-839c1abc23b6a4bbb9da807d54c5bbda" || payload["amount"] != tt.wantAmount {
+				t.Errorf("Client.CapturePreAuthPaymentAmount() sent %v, want amount %v", payload, tt.wantAmount)
+			}
+		})
+	}
+}
+
 func TestRefundPreAuthPayment(t *testing.T) {
 	handler := &testServer{}
 	server := httptest.NewServer(handler)
@@ -126,7 +168,7 @@ func TestRefundPreAuthPayment(t *testing.T) {
 				Message: "No transaction found",
 				Status:  "error",
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -183,7 +225,7 @@ func TestVoidPreAuthPayment(t *testing.T) {
 				Message: "No transaction found",
 				Status:  "error",
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,296 @@
+package ravetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ravepay "github.com/0sc/rave"
+)
+
+// OTP is the otp that validates the server's pending card and account charges
+const OTP = "12345"
+
+// CardAuth is how the server authorizes charges on a card
+type CardAuth string
+
+// The card auth models the server simulates
+const (
+	// CardAuthPIN suggests the PIN auth, then charges with the pin pending validation with the otp
+	CardAuthPIN CardAuth = "PIN"
+	// CardAuthVBV charges pending the card holder completing 3DS on the charge's auth url
+	CardAuthVBV CardAuth = "VBVSECURECODE"
+	// CardAuthInternational suggests the NOAUTH_INTERNATIONAL auth, then charges with the billing address
+	// pending the card holder completing 3DS on the charge's auth url
+	CardAuthInternational CardAuth = "NOAUTH_INTERNATIONAL"
+	// CardAuthNone approves charges without further auth
+	CardAuthNone CardAuth = "NOAUTH"
+)
+
+// Card is a card the server can charge
+type Card struct {
+	Number string
+	CVV    string
+	// PIN is the card's pin for CardAuthPIN cards
+	PIN  string
+	Auth CardAuth
+	// DeclineMessage, if set, declines charges on the card with the message e.g "Insufficient funds"
+	DeclineMessage string
+}
+
+// TestCards are the cards a new server can charge, modelled on rave's sandbox test cards
+// Any expiry date in the future is accepted
+var TestCards = []Card{
+	{Number: "5438898014560229", CVV: "789", PIN: "3310", Auth: CardAuthPIN},
+	{Number: "5531886652142950", CVV: "564", PIN: "3310", Auth: CardAuthPIN},
+	{Number: "4187427415564246", CVV: "828", Auth: CardAuthVBV},
+	{Number: "4556052704172643", CVV: "899", Auth: CardAuthInternational},
+	{Number: "5840406187553286", CVV: "116", Auth: CardAuthNone},
+	{Number: "5258585922666506", CVV: "883", PIN: "3310", Auth: CardAuthPIN, DeclineMessage: "Insufficient funds"},
+}
+
+// Account is a bank account the server can debit
+type Account struct {
+	Bank   string
+	Number string
+	// DeclineMessage, if set, declines charges on the account with the message
+	DeclineMessage string
+}
+
+// TestAccounts are the accounts a new server can debit, charges on them are validated with the otp
+var TestAccounts = []Account{
+	{Bank: "044", Number: "0690000031"},
+}
+
+// AddCard makes the card chargeable on the server, replacing any card with its number
+func (s *Server) AddCard(c Card) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cards[c.Number] = c
+}
+
+// AddAccount makes the account chargeable on the server, replacing any account with its bank and number
+func (s *Server) AddAccount(a Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts[a.Bank+"/"+a.Number] = a
+}
+
+// chargePayload is the decrypted client of a charge request
+type chargePayload struct {
+	PBFPubKey     string  `json:"PBFPubKey"`
+	Amount        float64 `json:"amount"`
+	ChargeType    string  `json:"charge_type"`
+	Currency      string  `json:"currency"`
+	Email         string  `json:"email"`
+	TxRef         string  `json:"txRef"`
+	PaymentType   string  `json:"payment_type"`
+	SuggestedAuth string  `json:"suggested_auth"`
+
+	CardNo         string `json:"cardno"`
+	Cvv            string `json:"cvv"`
+	Pin            string `json:"pin"`
+	BillingZip     string `json:"billingzip"`
+	BillingAddress string `json:"billingaddress"`
+
+	AccountBank   string `json:"account_bank"`
+	AccountNumber string `json:"account_number"`
+	// ussd charges name the account fields differently
+	USSDAccountBank string `json:"accountbank"`
+}
+
+func (s *Server) charge(r *http.Request) (interface{}, error) {
+	var req struct {
+		PBFPubKey string `json:"PBFPubKey"`
+		Client    string `json:"client"`
+		Alg       string `json:"alg"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.PBFPubKey != s.PublicKey {
+		return nil, errInvalidPublicKey
+	}
+	if req.Alg != "3DES-24" {
+		return nil, raveError("Unsupported encryption algorithm")
+	}
+	b, err := ravepay.DecryptChargePayload(req.Client, s.SecretKey)
+	if err != nil {
+		return nil, raveError("Invalid encrypted payload")
+	}
+	var p chargePayload
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, raveError("Invalid encrypted payload")
+	}
+	if p.Currency == "" {
+		p.Currency = "NGN"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch p.PaymentType {
+	case "card":
+		return s.chargeCard(p)
+	case "account":
+		return s.chargeAccount(p)
+	case "mpesa", "mobilemoneygh", "ussd":
+		return s.chargeMobile(p)
+	}
+	return nil, raveError("Unsupported payment type " + p.PaymentType)
+}
+
+// chargeCard charges the card by its auth model, s.mu must be held
+func (s *Server) chargeCard(p chargePayload) (interface{}, error) {
+	c, ok := s.cards[p.CardNo]
+	switch {
+	case !ok:
+		return nil, raveError("BIN not Found")
+	case p.Cvv != c.CVV:
+		return nil, raveError("Incorrect CVV")
+	case c.DeclineMessage != "":
+		return nil, raveError(c.DeclineMessage)
+	}
+
+	t := s.newTransaction(p, "FLW-MOCK-")
+	if strings.EqualFold(p.ChargeType, "preauth") {
+		t.AuthModel = string(CardAuthNone)
+		t.authorize()
+		s.txns = append(s.txns, t)
+		return success("V-COMP", t.chargeData()), nil
+	}
+
+	switch c.Auth {
+	case CardAuthPIN:
+		if !strings.EqualFold(p.SuggestedAuth, string(CardAuthPIN)) {
+			return suggestAuth(CardAuthPIN), nil
+		}
+		if p.Pin != c.PIN {
+			return nil, raveError("Incorrect PIN")
+		}
+		t.pendValidation(string(CardAuthPIN))
+	case CardAuthInternational:
+		if p.SuggestedAuth == "" {
+			return suggestAuth(CardAuthInternational), nil
+		}
+		if p.BillingZip == "" || p.BillingAddress == "" {
+			return nil, raveError("Billing address is required")
+		}
+		t.pendRedirect(p.SuggestedAuth, s.URL+string(EndpointAuth))
+	case CardAuthVBV:
+		t.pendRedirect(string(CardAuthVBV), s.URL+string(EndpointAuth))
+	default:
+		t.AuthModel = string(CardAuthNone)
+		t.succeed()
+	}
+	s.txns = append(s.txns, t)
+	return success("V-COMP", t.chargeData()), nil
+}
+
+func suggestAuth(auth CardAuth) interface{} {
+	return success("AUTH_SUGGESTION", map[string]interface{}{"suggested_auth": string(auth)})
+}
+
+// chargeAccount charges the account pending validation with the otp, s.mu must be held
+func (s *Server) chargeAccount(p chargePayload) (interface{}, error) {
+	a, ok := s.accounts[p.AccountBank+"/"+p.AccountNumber]
+	switch {
+	case !ok:
+		return nil, raveError("accountnumber is invalid")
+	case a.DeclineMessage != "":
+		return nil, raveError(a.DeclineMessage)
+	}
+
+	t := s.newTransaction(p, "ACHG-")
+	t.pendValidation("AUTH")
+	s.txns = append(s.txns, t)
+	return success("V-COMP", t.chargeData()), nil
+}
+
+// chargeMobile charges pending the customer completing the payment, see CompleteTransaction, s.mu must be held
+func (s *Server) chargeMobile(p chargePayload) (interface{}, error) {
+	if p.PaymentType == "ussd" && p.USSDAccountBank == "" {
+		return nil, raveError("accountbank is required")
+	}
+
+	t := s.newTransaction(p, "FLWMM")
+	t.AuthModel = strings.ToUpper(p.PaymentType)
+	t.Status = "pending"
+	t.ChargeResponseCode = "02"
+	t.ChargeResponseMessage = "pending charge processing"
+	s.txns = append(s.txns, t)
+
+	data := t.chargeData()
+	if p.PaymentType == "mpesa" {
+		data["business_number"] = "637104"
+		data["orderRef"] = fmt.Sprintf("URF_%08d", t.ID)
+	}
+	return success("V-COMP", data), nil
+}
+
+func (s *Server) validateCard(r *http.Request) (interface{}, error) {
+	var req struct {
+		PBFPubKey            string `json:"PBFPubKey"`
+		TransactionReference string `json:"transaction_reference"`
+		OTP                  string `json:"otp"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	t, err := s.validate(req.PBFPubKey, req.TransactionReference, req.OTP)
+	if err != nil {
+		return nil, err
+	}
+	return success("Charge Complete", map[string]interface{}{
+		"data": map[string]interface{}{"responsecode": "00", "responsemessage": "successful"},
+		"tx":   t.chargeData(),
+	}), nil
+}
+
+func (s *Server) validateAccount(r *http.Request) (interface{}, error) {
+	var req struct {
+		PBFPubKey            string `json:"PBFPubKey"`
+		TransactionReference string `json:"transactionreference"`
+		OTP                  string `json:"otp"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	t, err := s.validate(req.PBFPubKey, req.TransactionReference, req.OTP)
+	if err != nil {
+		return nil, err
+	}
+	return success("Charge Complete", t.chargeData()), nil
+}
+
+// validate completes the charge pending validation if the otp is the server's
+func (s *Server) validate(pubKey, flwRef, otp string) (*Transaction, error) {
+	if pubKey != s.PublicKey {
+		return nil, errInvalidPublicKey
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.findTransaction(flwRef, "")
+	switch {
+	case t == nil:
+		return nil, errNoTransaction
+	case t.Status != statusPendingValidation:
+		return nil, raveError("Transaction is not pending validation")
+	case otp != OTP:
+		return nil, raveError("Invalid OTP")
+	}
+	t.succeed()
+	return t, nil
+}
+
+// authorize completes the 3DS auth of the charge with the ref in the query, as the card holder would
+func (s *Server) authorize(r *http.Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.findTransaction(r.URL.Query().Get("ref"), "")
+	if t == nil || t.Status != statusPendingValidation {
+		return nil, errNoTransaction
+	}
+	t.succeed()
+	return success("Approved. Successful", map[string]interface{}{"flwRef": t.FlwRef}), nil
+}
@@ -0,0 +1,98 @@
+package ravetest
+
+import (
+	"net/http"
+	"time"
+)
+
+// FeeRate is the fraction of the amount the fee endpoint charges as rave's fee
+const FeeRate = 0.014
+
+// defaultRates are the exchange rates of a new server keyed by origin/destination currency
+var defaultRates = map[string]float64{
+	"USD/NGN": 360,
+	"GBP/NGN": 470,
+	"EUR/NGN": 410,
+	"USD/KES": 100,
+	"USD/GHS": 4.5,
+}
+
+// SetRate sets the exchange rate from the origin to the destination currency
+func (s *Server) SetRate(origin, destination string, rate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rates[origin+"/"+destination] = rate
+}
+
+// rate returns the exchange rate between the currencies, inverting the reverse rate if only that is set, s.mu must be held
+func (s *Server) rate(origin, destination string) (float64, bool) {
+	if origin == destination {
+		return 1, true
+	}
+	if rate, ok := s.rates[origin+"/"+destination]; ok {
+		return rate, true
+	}
+	if rate, ok := s.rates[destination+"/"+origin]; ok && rate != 0 {
+		return 1 / rate, true
+	}
+	return 0, false
+}
+
+func (s *Server) forex(r *http.Request) (interface{}, error) {
+	var req struct {
+		Amount              float64 `json:"amount"`
+		OriginCurrency      string  `json:"origin_currency"`
+		DestinationCurrency string  `json:"destination_currency"`
+		SECKEY              string  `json:"SECKEY"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.SECKEY != s.SecretKey {
+		return nil, errInvalidSecretKey
+	}
+
+	s.mu.Lock()
+	rate, ok := s.rate(req.OriginCurrency, req.DestinationCurrency)
+	s.mu.Unlock()
+	if !ok {
+		return nil, raveError("Rate not found for " + req.OriginCurrency + " to " + req.DestinationCurrency)
+	}
+
+	data := map[string]interface{}{
+		"rate":                rate,
+		"origincurrency":      req.OriginCurrency,
+		"destinationcurrency": req.DestinationCurrency,
+		"lastupdated":         time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
+	}
+	if req.Amount > 0 {
+		data["original_amount"] = req.Amount
+		data["converted_amount"] = round(req.Amount * rate)
+	}
+	return success("Rate Fetched", data), nil
+}
+
+func (s *Server) fee(r *http.Request) (interface{}, error) {
+	var req struct {
+		Amount    float64 `json:"amount"`
+		PBFPubKey string  `json:"PBFPubKey"`
+		Currency  string  `json:"currency"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.PBFPubKey != s.PublicKey {
+		return nil, errInvalidPublicKey
+	}
+	if req.Amount <= 0 {
+		return nil, raveError("Amount must be positive")
+	}
+
+	fee := round(req.Amount * FeeRate)
+	return success("Charged fee", map[string]interface{}{
+		"charge_amount": round(req.Amount + fee),
+		"fee":           fee,
+		"merchantfee":   0,
+		"ravefee":       fee,
+	}), nil
+}
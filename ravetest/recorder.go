@@ -0,0 +1,310 @@
+package ravetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	ravepay "github.com/0sc/rave"
+)
+
+// Exchange is a request to rave and its response as stored in fixture files
+// Encrypted charge payloads are stored decrypted, and secret keys, card numbers, cvvs, pins and otps are masked
+type Exchange struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded request of an exchange
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded response of an exchange
+type RecordedResponse struct {
+	StatusCode  int             `json:"status_code"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+	// Text holds bodies that aren't json
+	Text string `json:"text,omitempty"`
+}
+
+// RecordingTransport is an http.RoundTripper that records the exchanges it makes to a fixture file for ReplayTransport
+// Use it as the transport of the client's http client, see ravepay.WithHTTPClient
+//
+//	rec := ravetest.NewRecordingTransport("testdata/card_charge.json", secretKey, nil)
+//	client := rave.NewClient(rave.WithKeys(publicKey, secretKey), rave.WithMode("test"), rave.WithHTTPClient(&http.Client{Transport: rec}))
+type RecordingTransport struct {
+	// Path is the fixture file, it's rewritten with every recorded exchange
+	Path string
+	// SecretKey decrypts charge payloads so they're recorded in the clear
+	SecretKey string
+	// Transport makes the requests, http.DefaultTransport is used if it's nil
+	Transport http.RoundTripper
+
+	mu        sync.Mutex
+	exchanges []Exchange
+}
+
+// NewRecordingTransport returns a transport recording the exchanges made with the transport to the fixture file
+func NewRecordingTransport(path, secretKey string, transport http.RoundTripper) *RecordingTransport {
+	return &RecordingTransport{Path: path, SecretKey: secretKey, Transport: transport}
+}
+
+// RoundTrip makes the request and records it with its response
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	e := Exchange{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  ravepay.Redact(req.URL.RawQuery),
+			Body:   normalizeBody(reqBody, t.SecretKey),
+		},
+		Response: RecordedResponse{StatusCode: resp.StatusCode, ContentType: resp.Header.Get("Content-Type")},
+	}
+	redacted := []byte(ravepay.Redact(string(respBody)))
+	if json.Valid(redacted) {
+		e.Response.Body = redacted
+	} else {
+		e.Response.Text = string(redacted)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.exchanges = append(t.exchanges, e)
+	if err := writeFixture(t.Path, t.exchanges); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Exchanges returns the exchanges recorded so far
+func (t *RecordingTransport) Exchanges() []Exchange {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Exchange(nil), t.exchanges...)
+}
+
+// ReplayTransport is an http.RoundTripper that responds to requests with the exchanges recorded by RecordingTransport
+// without making any request
+// Requests match an exchange by method, path, query and body, each exchange is replayed once in the order recorded
+// Queries match with their sensitive params masked as they're recorded, regardless of the order of their params
+type ReplayTransport struct {
+	// SecretKey decrypts charge payloads so they're matched in the clear
+	SecretKey string
+	// IgnoreFields are json fields left out when matching bodies, at any depth e.g txRef or PBFPubKey,
+	// and query params left out when matching queries, for fields that change between runs
+	IgnoreFields []string
+
+	mu        sync.Mutex
+	exchanges []Exchange
+	replayed  []bool
+}
+
+// NewReplayTransport returns a transport replaying the exchanges in the fixture file
+func NewReplayTransport(path, secretKey string, ignoreFields ...string) (*ReplayTransport, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ravetest: reading fixture: %w", err)
+	}
+	var exchanges []Exchange
+	if err := json.Unmarshal(b, &exchanges); err != nil {
+		return nil, fmt.Errorf("ravetest: parsing fixture %s: %w", path, err)
+	}
+	return &ReplayTransport{
+		SecretKey:    secretKey,
+		IgnoreFields: ignoreFields,
+		exchanges:    exchanges,
+		replayed:     make([]bool, len(exchanges)),
+	}, nil
+}
+
+// RoundTrip responds with the first exchange matching the request that hasn't been replayed
+// It returns an error if there's none
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	key := matchKey(normalizeBody(body, t.SecretKey), t.IgnoreFields)
+	query := matchQuery(ravepay.Redact(req.URL.RawQuery), t.IgnoreFields)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, e := range t.exchanges {
+		if t.replayed[i] || e.Request.Method != req.Method || e.Request.Path != req.URL.Path {
+			continue
+		}
+		if matchQuery(e.Request.Query, t.IgnoreFields) != query {
+			continue
+		}
+		if matchKey(e.Request.Body, t.IgnoreFields) != key {
+			continue
+		}
+		t.replayed[i] = true
+		return e.Response.response(req), nil
+	}
+	return nil, fmt.Errorf("ravetest: no recorded exchange matches %s %s %s", req.Method, ravepay.Redact(req.URL.RequestURI()), ravepay.Redact(string(body)))
+}
+
+// Unreplayed returns the exchanges that haven't been replayed
+// A test can check it's empty to make sure the code under test made every recorded request
+func (t *ReplayTransport) Unreplayed() []Exchange {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var list []Exchange
+	for i, e := range t.exchanges {
+		if !t.replayed[i] {
+			list = append(list, e)
+		}
+	}
+	return list
+}
+
+func (r RecordedResponse) response(req *http.Request) *http.Response {
+	body := []byte(r.Body)
+	if r.Text != "" {
+		body = []byte(r.Text)
+	}
+	header := http.Header{}
+	if r.ContentType != "" {
+		header.Set("Content-Type", r.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// readBody reads the body and replaces it with a copy so it can still be sent or read
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+// normalizeBody decrypts the client of charge payloads and masks the sensitive fields of the body
+func normalizeBody(body []byte, secretKey string) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if client, ok := payload["client"].(string); ok && secretKey != "" {
+			if decrypted, err := ravepay.DecryptChargePayload(client, secretKey); err == nil && json.Valid(decrypted) {
+				payload["client"] = json.RawMessage(decrypted)
+				body, _ = json.Marshal(payload)
+			}
+		}
+	}
+
+	redacted := []byte(ravepay.Redact(string(body)))
+	if !json.Valid(redacted) {
+		b, _ := json.Marshal(string(redacted))
+		return b
+	}
+	return redacted
+}
+
+// matchQuery returns the query with its params sorted and the ignored params left out
+func matchQuery(query string, ignoreFields []string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+	for _, f := range ignoreFields {
+		values.Del(f)
+	}
+	return values.Encode()
+}
+
+// matchKey is the canonical json of the body without the ignored fields
+func matchKey(body json.RawMessage, ignoreFields []string) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	ignored := map[string]bool{}
+	for _, f := range ignoreFields {
+		ignored[f] = true
+	}
+	b, _ := json.Marshal(dropFields(v, ignored))
+	return string(b)
+}
+
+func dropFields(v interface{}, ignored map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if ignored[k] {
+				delete(v, k)
+				continue
+			}
+			v[k] = dropFields(field, ignored)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = dropFields(item, ignored)
+		}
+	}
+	return v
+}
+
+func writeFixture(path string, exchanges []Exchange) error {
+	b, err := json.MarshalIndent(exchanges, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ravetest: encoding fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("ravetest: writing fixture: %w", err)
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("ravetest: writing fixture: %w", err)
+	}
+	return nil
+}
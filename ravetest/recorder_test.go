@@ -0,0 +1,147 @@
+package ravetest_test
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ravepay "github.com/0sc/rave"
+	"github.com/0sc/rave/ravetest"
+)
+
+// chargeAndVerify charges the pin test card through to validation and verifies the charge
+func chargeAndVerify(c *ravepay.Client, txRef string) error {
+	card := testCard("5438898014560229", "789")
+	f := c.NewCardChargeFlow(chargeRequest(txRef), card)
+	if _, err := f.Start(); err != nil {
+		return err
+	}
+	if _, err := f.SupplyPIN("3310"); err != nil {
+		return err
+	}
+	if _, err := f.SubmitOTP(ravetest.OTP); err != nil {
+		return err
+	}
+	tvc := &ravepay.TxnVerificationChecklist{FlwRef: f.Response().Data.FlwRef, Amount: ravepay.NewMoney(300000, "NGN"), TransactionCurrency: "NGN"}
+	if _, errs := c.VerifyTransaction(tvc); len(errs) != 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := ravetest.NewServer()
+	fixture := filepath.Join(t.TempDir(), "testdata", "card_charge.json")
+
+	rec := ravetest.NewRecordingTransport(fixture, srv.SecretKey, nil)
+	recorder := newClient(srv, ravepay.WithHTTPClient(&http.Client{Transport: rec}))
+	if err := chargeAndVerify(recorder, "MXX-recorded"); err != nil {
+		t.Fatalf("recording the charge failed: %v", err)
+	}
+	srv.Close()
+
+	b, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatalf("reading the fixture failed: %v", err)
+	}
+	for _, secret := range []string{"5438898014560229", `"789"`, `"3310"`, srv.SecretKey} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("the fixture has %s in it", secret)
+		}
+	}
+	if !strings.Contains(string(b), `"txRef": "MXX-recorded"`) {
+		t.Errorf("the fixture doesn't have the decrypted charge payload:\n%s", b)
+	}
+	if n := len(rec.Exchanges()); n != 4 {
+		t.Errorf("recorded %d exchanges, want 4", n)
+	}
+
+	tests := []struct {
+		name         string
+		txRef        string
+		ignoreFields []string
+		wantErr      bool
+	}{
+		{
+			name:  "replays the recorded exchanges",
+			txRef: "MXX-recorded",
+		},
+		{
+			name:         "replays with the ignored fields changed",
+			txRef:        "MXX-replayed",
+			ignoreFields: []string{"txRef"},
+		},
+		{
+			name:    "fails requests without a recorded exchange",
+			txRef:   "MXX-replayed",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replay, err := ravetest.NewReplayTransport(fixture, srv.SecretKey, tt.ignoreFields...)
+			if err != nil {
+				t.Fatalf("NewReplayTransport() error = %v", err)
+			}
+			// the server is closed, so every response comes from the fixture
+			c := newClient(srv, ravepay.WithHTTPClient(&http.Client{Transport: replay}))
+
+			err = chargeAndVerify(c, tt.txRef)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replaying the charge error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(replay.Unreplayed()) != 0 {
+				t.Errorf("exchanges weren't replayed: %v", replay.Unreplayed())
+			}
+		})
+	}
+}
+
+func TestReplayTransport_query(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "transfers.json")
+	exchanges := `[
+  {"request": {"method": "GET", "path": "/v2/gpx/transfers", "query": "` + ravepay.Redact("page=1&seckey=sec-key") + `"}, "response": {"status_code": 200, "body": {"page":1}}},
+  {"request": {"method": "GET", "path": "/v2/gpx/transfers", "query": "` + ravepay.Redact("page=2&seckey=sec-key") + `"}, "response": {"status_code": 200, "body": {"page":2}}}
+]`
+	if err := os.WriteFile(fixture, []byte(exchanges), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantBody string
+		wantErr  bool
+	}{
+		{name: "matches the query", query: "page=2&seckey=sec-key", wantBody: `{"page":2}`},
+		{name: "matches the query with its params in another order", query: "seckey=sec-key&page=1", wantBody: `{"page":1}`},
+		{name: "fails queries without a recorded exchange", query: "page=3&seckey=sec-key", wantErr: true},
+	}
+	replay, err := ravetest.NewReplayTransport(fixture, "")
+	if err != nil {
+		t.Fatalf("NewReplayTransport() error = %v", err)
+	}
+	c := &http.Client{Transport: replay}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := c.Get("http://rave.test/v2/gpx/transfers?" + tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replaying the request error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer resp.Body.Close()
+			b, _ := io.ReadAll(resp.Body)
+			if string(b) != tt.wantBody {
+				t.Errorf("replayed %s, want %s", b, tt.wantBody)
+			}
+		})
+	}
+	if n := len(replay.Unreplayed()); n != 0 {
+		t.Errorf("%d exchanges weren't replayed", n)
+	}
+}
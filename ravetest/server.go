@@ -0,0 +1,288 @@
+// Package ravetest provides an in-process fake of rave's api for testing code that uses the rave package
+// The fake decrypts charge payloads, keeps the transactions it charges, and simulates the charge, validate,
+// verify, xrequery, refund, preauth, fee, forex and banks endpoints with rave's sandbox test cards and otp
+//
+//	srv := ravetest.NewServer()
+//	defer srv.Close()
+//	client := rave.NewClient(rave.WithKeys(srv.PublicKey, srv.SecretKey), rave.WithBaseURL(srv.URL))
+package ravetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The keys the server accepts by default
+const (
+	PublicKey = "FLWPUBK-6b32914d4d60cb85d8eb73db9ded04ea-X"
+	SecretKey = "FLWSECK-6b32914d4d60cb85d8eb73db9ded04ea-X"
+)
+
+// Endpoint is the path of a rave api endpoint the server simulates
+type Endpoint string
+
+// The endpoints the server simulates
+const (
+	EndpointCharge          Endpoint = "/flwv3-pug/getpaidx/api/charge"
+	EndpointValidateCard    Endpoint = "/flwv3-pug/getpaidx/api/validatecharge"
+	EndpointValidateAccount Endpoint = "/flwv3-pug/getpaidx/api/validate"
+	EndpointVerify          Endpoint = "/flwv3-pug/getpaidx/api/verify"
+	EndpointXRequery        Endpoint = "/flwv3-pug/getpaidx/api/xrequery"
+	EndpointRefund          Endpoint = "/gpx/merchant/transactions/refund"
+	EndpointCapture         Endpoint = "/flwv3-pug/getpaidx/api/capture"
+	EndpointRefundOrVoid    Endpoint = "/flwv3-pug/getpaidx/api/refundorvoid"
+	EndpointFee             Endpoint = "/flwv3-pug/getpaidx/api/fee"
+	EndpointForex           Endpoint = "/flwv3-pug/getpaidx/api/forex"
+	EndpointBanks           Endpoint = "/flwv3-pug/getpaidx/api/flwpbf-banks.js"
+	// EndpointAuth is the server's stand in for the 3DS pages rave redirects card holders to
+	// visiting a charge's auth url completes the charge
+	EndpointAuth Endpoint = "/mockvbvpage"
+)
+
+// Failure is a failure scripted for a request to an endpoint
+type Failure struct {
+	// StatusCode is the http status of the failed response, 200 if only Message is set
+	StatusCode int
+	// Message is rave's error message in the failed response e.g Card declined
+	Message string
+	// Delay holds the response back, the request is still processed after the delay if no status or message is set
+	// so a client timing out before the delay passes sees the request go through on rave like a lost response
+	Delay time.Duration
+}
+
+// Declined returns a failure declining the request with the message e.g "Insufficient funds"
+func Declined(message string) Failure {
+	return Failure{StatusCode: http.StatusOK, Message: message}
+}
+
+// ServerError returns a failure responding with the 5xx status
+func ServerError(statusCode int) Failure {
+	return Failure{StatusCode: statusCode, Message: http.StatusText(statusCode)}
+}
+
+// Timeout returns a failure holding the response back for the duration
+func Timeout(d time.Duration) Failure {
+	return Failure{Delay: d}
+}
+
+// Server is an httptest server standing in for rave's api
+// Point a rave client at its URL with its keys to use it
+type Server struct {
+	*httptest.Server
+	PublicKey string
+	SecretKey string
+
+	mu       sync.Mutex
+	cards    map[string]Card
+	accounts map[string]Account
+	txns     []*Transaction
+	failures map[Endpoint][]Failure
+	requests map[Endpoint]int
+	rates    map[string]float64
+	banks    []Bank
+}
+
+// NewServer starts a server with rave's sandbox test cards, account, banks and exchange rates
+// The caller should Close the server when done
+func NewServer() *Server {
+	s := &Server{
+		PublicKey: PublicKey,
+		SecretKey: SecretKey,
+		cards:     map[string]Card{},
+		accounts:  map[string]Account{},
+		failures:  map[Endpoint][]Failure{},
+		requests:  map[Endpoint]int{},
+		rates:     map[string]float64{},
+		banks:     append([]Bank(nil), TestBanks...),
+	}
+	for _, c := range TestCards {
+		s.AddCard(c)
+	}
+	for _, a := range TestAccounts {
+		s.AddAccount(a)
+	}
+	for pair, rate := range defaultRates {
+		s.rates[pair] = rate
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Fail scripts failures for the next requests to the endpoint, one failure per request
+func (s *Server) Fail(e Endpoint, failures ...Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[e] = append(s.failures[e], failures...)
+}
+
+// Requests returns the number of requests the server received for the endpoint
+func (s *Server) Requests(e Endpoint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[e]
+}
+
+// Transaction returns the transaction with the flw ref or tx ref
+func (s *Server) Transaction(ref string) (Transaction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.findTransaction(ref, ref); t != nil {
+		return *t, true
+	}
+	return Transaction{}, false
+}
+
+// Transactions returns the transactions the server has charged, oldest first
+func (s *Server) Transactions() []Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]Transaction, len(s.txns))
+	for i, t := range s.txns {
+		list[i] = *t
+	}
+	return list
+}
+
+// CompleteTransaction completes the pending charge with the flw ref or tx ref as the customer would
+// e.g by approving a mobile money prompt or dialing a ussd code
+// It reports false if there's no such pending charge
+func (s *Server) CompleteTransaction(ref string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.findTransaction(ref, ref)
+	if t == nil || !t.pending() {
+		return false
+	}
+	t.succeed()
+	return true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e := Endpoint(r.URL.Path)
+	s.mu.Lock()
+	s.requests[e]++
+	var f Failure
+	if queued := s.failures[e]; len(queued) > 0 {
+		f, s.failures[e] = queued[0], queued[1:]
+	}
+	s.mu.Unlock()
+
+	if f.Delay > 0 {
+		// the request is processed even if the client gives up waiting, as rave would
+		select {
+		case <-time.After(f.Delay):
+		case <-r.Context().Done():
+		}
+	}
+	if f.StatusCode != 0 || f.Message != "" {
+		status := f.StatusCode
+		if status == 0 {
+			status = http.StatusOK
+		}
+		writeError(w, status, f.Message)
+		return
+	}
+
+	handlers := map[Endpoint]func(*http.Request) (interface{}, error){
+		EndpointCharge:          s.charge,
+		EndpointValidateCard:    s.validateCard,
+		EndpointValidateAccount: s.validateAccount,
+		EndpointVerify:          s.verify,
+		EndpointXRequery:        s.xrequery,
+		EndpointRefund:          s.refund,
+		EndpointCapture:         s.capture,
+		EndpointRefundOrVoid:    s.refundOrVoid,
+		EndpointFee:             s.fee,
+		EndpointForex:           s.forex,
+		EndpointBanks:           s.listBanks,
+		EndpointAuth:            s.authorize,
+	}
+	handler, ok := handlers[e]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	resp, err := handler(r)
+	if err != nil {
+		writeError(w, http.StatusOK, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// raveError is an error rave reports with a 200 status and an error status in the body
+type raveError string
+
+func (e raveError) Error() string {
+	return string(e)
+}
+
+const (
+	errInvalidPublicKey = raveError("Invalid public key")
+	errInvalidSecretKey = raveError("Invalid secret key")
+	errNoTransaction    = raveError("No transaction found")
+)
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "error",
+		"message": message,
+		"data":    map[string]interface{}{"code": strings.ToUpper(strings.ReplaceAll(message, " ", "_")), "message": message},
+	})
+}
+
+func success(message string, data interface{}) map[string]interface{} {
+	return map[string]interface{}{"status": "success", "message": message, "data": data}
+}
+
+// decode decodes the json request body into v
+func decode(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return raveError(fmt.Sprintf("Invalid request body: %v", err))
+	}
+	return nil
+}
+
+// findTransaction returns the latest transaction with the flw ref or tx ref, s.mu must be held
+func (s *Server) findTransaction(flwRef, txRef string) *Transaction {
+	for i := len(s.txns) - 1; i >= 0; i-- {
+		t := s.txns[i]
+		if (flwRef != "" && t.FlwRef == flwRef) || (txRef != "" && t.TxRef == txRef) {
+			return t
+		}
+	}
+	return nil
+}
+
+// Bank is a bank the banks endpoint lists
+type Bank struct {
+	Code            string `json:"bankcode"`
+	Name            string `json:"bankname"`
+	Internetbanking bool   `json:"internetbanking"`
+}
+
+// TestBanks are the banks a new server lists
+var TestBanks = []Bank{
+	{Code: "044", Name: "ACCESS BANK NIGERIA"},
+	{Code: "058", Name: "GTBANK PLC"},
+	{Code: "011", Name: "FIRST BANK PLC"},
+	{Code: "057", Name: "ZENITH BANK PLC"},
+}
+
+func (s *Server) listBanks(r *http.Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	banks := append([]Bank(nil), s.banks...)
+	sort.Slice(banks, func(i, j int) bool { return banks[i].Code < banks[j].Code })
+	return banks, nil
+}
@@ -0,0 +1,333 @@
+package ravetest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	ravepay "github.com/0sc/rave"
+	"github.com/0sc/rave/ravetest"
+)
+
+func newClient(srv *ravetest.Server, opts ...ravepay.ClientOption) *ravepay.Client {
+	opts = append([]ravepay.ClientOption{ravepay.WithKeys(srv.PublicKey, srv.SecretKey), ravepay.WithBaseURL(srv.URL)}, opts...)
+	return ravepay.NewClient(opts...)
+}
+
+func chargeRequest(txRef string) *ravepay.ChargeRequest {
+	return &ravepay.ChargeRequest{TxRef: txRef, Amount: ravepay.NewMoney(300000, "NGN"), Email: "tester@flutter.co"}
+}
+
+// testExpiryYear is the expiry year of the test cards, a year from now so they never expire
+var testExpiryYear = time.Now().AddDate(1, 0, 0).Format("06")
+
+func testCard(number, cvv string) *ravepay.Card {
+	return &ravepay.Card{CardNo: number, Cvv: cvv, Expirymonth: "09", Expiryyear: testExpiryYear, Currency: "NGN", Country: "NG"}
+}
+
+func verify(t *testing.T, c *ravepay.Client, flwRef string, amount ravepay.Money) {
+	t.Helper()
+	tvc := &ravepay.TxnVerificationChecklist{FlwRef: flwRef, Amount: amount, TransactionCurrency: "NGN"}
+	if _, errs := c.VerifyTransaction(tvc); len(errs) != 0 {
+		t.Errorf("VerifyTransaction() errs = %v", errs)
+	}
+	if _, errs := c.VerifyXRequeryTransaction(&ravepay.TxnVerificationChecklist{Flwref: flwRef, FlwRef: flwRef, Amount: amount, TransactionCurrency: "NGN"}); len(errs) != 0 {
+		t.Errorf("VerifyXRequeryTransaction() errs = %v", errs)
+	}
+}
+
+func TestServer_cardFlows(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+
+	tests := []struct {
+		name  string
+		card  *ravepay.Card
+		steps func(t *testing.T, f *ravepay.CardChargeFlow) (ravepay.CardChargeAction, error)
+	}{
+		{
+			name: "pin cards are validated with the otp",
+			card: testCard("5438898014560229", "789"),
+			steps: func(t *testing.T, f *ravepay.CardChargeFlow) (ravepay.CardChargeAction, error) {
+				if action, err := f.SupplyPIN("3310"); action != ravepay.CardChargeActionOTP {
+					return action, err
+				}
+				return f.SubmitOTP(ravetest.OTP)
+			},
+		},
+		{
+			name: "vbv cards are completed on the auth url",
+			card: testCard("4187427415564246", "828"),
+			steps: func(t *testing.T, f *ravepay.CardChargeFlow) (ravepay.CardChargeAction, error) {
+				if f.Action() != ravepay.CardChargeActionRedirect {
+					return f.Action(), nil
+				}
+				resp, err := http.Get(f.AuthURL())
+				if err != nil {
+					return f.Action(), err
+				}
+				resp.Body.Close()
+				return ravepay.CardChargeActionDone, nil
+			},
+		},
+		{
+			name: "international cards are charged with the billing address",
+			card: testCard("4556052704172643", "899"),
+			steps: func(t *testing.T, f *ravepay.CardChargeFlow) (ravepay.CardChargeAction, error) {
+				action, err := f.SupplyBillingAddress(ravepay.BillingAddress{Address: "470 Mundet PI", City: "Hillside", State: "NJ", Country: "US", Zip: "07205"})
+				if action != ravepay.CardChargeActionRedirect {
+					return action, err
+				}
+				srv.CompleteTransaction(f.Response().Data.FlwRef)
+				return ravepay.CardChargeActionDone, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := c.NewCardChargeFlow(chargeRequest("MXX-"+tt.card.CardNo), tt.card)
+			if _, err := f.Start(); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			if action, err := tt.steps(t, f); action != ravepay.CardChargeActionDone || err != nil {
+				t.Fatalf("the flow ended on %s, error = %v", action, err)
+			}
+			verify(t, c, f.Response().Data.FlwRef, ravepay.NewMoney(300000, "NGN"))
+		})
+	}
+}
+
+func TestServer_rejectsCharges(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+
+	tests := []struct {
+		name    string
+		client  *ravepay.Client
+		card    *ravepay.Card
+		wantErr error
+	}{
+		{
+			name:    "declines the insufficient funds card",
+			client:  c,
+			card:    &ravepay.Card{CardNo: "5258585922666506", Cvv: "883", Pin: "3310", Expirymonth: "09", Expiryyear: testExpiryYear, Currency: "NGN", Country: "NG"},
+			wantErr: ravepay.ErrDeclined,
+		},
+		{
+			name:    "rejects unknown cards",
+			client:  c,
+			card:    testCard("5399838383838381", "470"),
+			wantErr: ravepay.ErrValidation,
+		},
+		{
+			name:    "rejects the wrong keys",
+			client:  ravepay.NewClient(ravepay.WithKeys("FLWPUBK-wrong-X", srv.SecretKey), ravepay.WithBaseURL(srv.URL)),
+			card:    testCard("5438898014560229", "789"),
+			wantErr: ravepay.ErrAuthentication,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.client.Charge(chargeRequest("MXX-rejected"), tt.card); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Charge() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if txns := srv.Transactions(); len(txns) != 0 {
+		t.Errorf("rejected charges made transactions %v", txns)
+	}
+}
+
+func TestServer_accountCharge(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+
+	resp, err := c.Charge(chargeRequest("MXX-account"), &ravepay.Account{AccountBank: "044", AccountNumber: "0690000031", Country: "NG", Currency: "NGN"})
+	if err != nil {
+		t.Fatalf("Charge() error = %v", err)
+	}
+	if _, err := c.OTPValidation(resp, "00000"); err == nil {
+		t.Errorf("OTPValidation() with the wrong otp error = nil")
+	}
+	if _, err := c.OTPValidation(resp, ravetest.OTP); err != nil {
+		t.Fatalf("OTPValidation() error = %v", err)
+	}
+
+	txn, ok := srv.Transaction("MXX-account")
+	if !ok || txn.Status != "successful" || txn.PaymentType != "account" {
+		t.Errorf("Transaction() = %+v, %v, want a successful account charge", txn, ok)
+	}
+	verify(t, c, resp.Data.FlwRef, ravepay.NewMoney(300000, "NGN"))
+
+	if _, err := c.Refund(resp.Data.FlwRef); err != nil {
+		t.Errorf("Refund() error = %v", err)
+	}
+	if _, err := c.Refund(resp.Data.FlwRef); !errors.Is(err, ravepay.ErrValidation) {
+		t.Errorf("Refund() of a refunded charge error = %v, want %v", err, ravepay.ErrValidation)
+	}
+}
+
+func TestServer_pendingCharges(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+
+	cr := chargeRequest("MXX-mpesa")
+	cr.Amount = ravepay.NewMoney(10000, "KES")
+	cr.PhoneNumber = "+254712345678"
+	resp, err := c.Charge(cr, &ravepay.Mpesa{Currency: "KES", Country: "KE"})
+	if err != nil {
+		t.Fatalf("Charge() error = %v", err)
+	}
+	if resp.Data.Status != "pending" || resp.Data.BusinessNumber == "" {
+		t.Errorf("Charge() = %+v, want a pending mpesa charge", resp.Data)
+	}
+	if !srv.CompleteTransaction("MXX-mpesa") {
+		t.Fatalf("CompleteTransaction() = false")
+	}
+	if txn, _ := srv.Transaction(resp.Data.FlwRef); txn.Status != "successful" {
+		t.Errorf("the completed charge is %s", txn.Status)
+	}
+	if srv.CompleteTransaction("MXX-mpesa") {
+		t.Errorf("CompleteTransaction() of a completed charge = true")
+	}
+}
+
+func TestServer_preAuth(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+
+	p := c.NewPreAuth(chargeRequest("MXX-preauth"), testCard("5840406187553286", "116"))
+	if _, err := p.Authorize(); err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if _, err := p.Capture(ravepay.NewMoney(200000, "NGN")); err != nil {
+		t.Fatalf("Capture() error = %v", err)
+	}
+	if _, err := p.Refund(); err != nil {
+		t.Fatalf("Refund() error = %v", err)
+	}
+
+	txn, _ := srv.Transaction(p.FlwRef)
+	if txn.Status != "refunded" || txn.CapturedAmount != 2000 || txn.RefundedAmount != 2000 {
+		t.Errorf("Transaction() = %+v, want the captured part refunded", txn)
+	}
+
+	voided := c.NewPreAuth(chargeRequest("MXX-voided"), testCard("5840406187553286", "116"))
+	voided.Authorize()
+	if _, err := voided.Void(); err != nil {
+		t.Errorf("Void() error = %v", err)
+	}
+	if txn, _ := srv.Transaction(voided.FlwRef); txn.Status != "voided" {
+		t.Errorf("the voided preauth is %s", txn.Status)
+	}
+}
+
+func TestServer_feesRatesAndBanks(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+
+	fee, err := c.GetFee(&ravepay.GetFeeRequest{Amount: ravepay.NewMoney(100000, "NGN"), Currency: "NGN"})
+	if err != nil || fee.Data.Fee.Minor != 1400 || fee.Data.ChargeAmount.Minor != 101400 {
+		t.Errorf("GetFee() = %+v, %v, want a fee of 14", fee.Data, err)
+	}
+
+	srv.SetRate("USD", "NGN", 365)
+	amount := ravepay.NewMoney(1000, "USD")
+	rate, err := c.ForexRate(&ravepay.ForexParams{OriginCurrency: "USD", DestinationCurrency: "NGN", Amount: &amount})
+	if err != nil || rate.Data.Rate != 365 || rate.Data.ConvertedAmount.Minor != 365000 {
+		t.Errorf("ForexRate() = %+v, %v, want 10 USD at 365", rate.Data, err)
+	}
+	if rate, err := c.ForexRate(&ravepay.ForexParams{OriginCurrency: "NGN", DestinationCurrency: "GBP"}); err != nil || rate.Data.Rate != 1.0/470 {
+		t.Errorf("ForexRate() = %+v, %v, want the inverse of GBP to NGN", rate.Data, err)
+	}
+
+	banks, err := c.ListBanks()
+	if err != nil || len(banks) != len(ravetest.TestBanks) {
+		t.Errorf("ListBanks() = %v, %v, want the test banks", banks, err)
+	}
+}
+
+func TestServer_Fail(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+	policy := &ravepay.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryOn: []int{http.StatusBadGateway}}
+	c := newClient(srv, ravepay.WithRetryPolicy(policy))
+	card := testCard("5840406187553286", "116")
+
+	srv.Fail(ravetest.EndpointCharge, ravetest.ServerError(http.StatusBadGateway))
+	if _, err := c.Charge(chargeRequest("MXX-retried"), card); err != nil {
+		t.Errorf("Charge() after a 502 error = %v", err)
+	}
+	if n := srv.Requests(ravetest.EndpointCharge); n != 2 {
+		t.Errorf("the charge was sent %d times, want 2", n)
+	}
+
+	srv.Fail(ravetest.EndpointCharge, ravetest.Declined("Insufficient funds"))
+	if _, err := c.Charge(chargeRequest("MXX-declined"), card); !errors.Is(err, ravepay.ErrDeclined) {
+		t.Errorf("Charge() error = %v, want %v", err, ravepay.ErrDeclined)
+	}
+
+	srv.Fail(ravetest.EndpointCharge, ravetest.Timeout(200*time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := newClient(srv).ChargeContext(ctx, chargeRequest("MXX-lost"), card); err == nil {
+		t.Errorf("ChargeContext() past its deadline error = nil")
+	}
+	// the charge goes through on the server even though the client gave up on it
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := srv.Transaction("MXX-lost"); ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("the timed out charge wasn't made")
+}
+
+func TestServer_xrequeryAttempts(t *testing.T) {
+	srv := ravetest.NewServer()
+	defer srv.Close()
+	c := newClient(srv)
+
+	pending, err := c.Charge(chargeRequest("MXX-attempts"), &ravepay.Account{AccountBank: "044", AccountNumber: "0690000031", Country: "NG", Currency: "NGN"})
+	if err != nil {
+		t.Fatalf("charging the account failed: %v", err)
+	}
+	charged, err := c.Charge(chargeRequest("MXX-attempts"), testCard("5840406187553286", "116"))
+	if err != nil {
+		t.Fatalf("charging the card failed: %v", err)
+	}
+
+	attempts, err := c.TransactionAttempts("MXX-attempts")
+	if err != nil {
+		t.Fatalf("TransactionAttempts() error = %v", err)
+	}
+	if len(attempts.Data) != 2 || attempts.Data[0].Flwref != pending.Data.FlwRef {
+		t.Fatalf("TransactionAttempts() = %+v, want the account and card attempts", attempts.Data)
+	}
+	resp, ok := attempts.FirstSuccessful()
+	if !ok || resp.Data.Flwref != charged.Data.FlwRef {
+		t.Fatalf("FirstSuccessful() = %+v, %v, want the card attempt", resp.Data, ok)
+	}
+	tvc := &ravepay.TxnVerificationChecklist{TxRef: "MXX-attempts", Amount: ravepay.NewMoney(300000, "NGN"), TransactionCurrency: "NGN"}
+	if errs := tvc.Verify(resp); len(errs) != 0 {
+		t.Errorf("Verify() of the first successful attempt errs = %v", errs)
+	}
+
+	tvc = &ravepay.TxnVerificationChecklist{Txref: "MXX-attempts", Amount: ravepay.NewMoney(300000, "NGN"), TransactionCurrency: "NGN"}
+	if resp, errs := c.VerifyXRequeryTransaction(tvc); len(errs) != 0 || resp.Data.Flwref != charged.Data.FlwRef {
+		t.Errorf("VerifyXRequeryTransaction() of every attempt = %+v, %v, want the latest attempt", resp.Data, errs)
+	}
+
+	if _, err := c.TransactionAttempts("MXX-unknown"); !errors.Is(err, ravepay.ErrValidation) {
+		t.Errorf("TransactionAttempts() of an unknown tx ref error = %v, want %v", err, ravepay.ErrValidation)
+	}
+}
@@ -0,0 +1,361 @@
+package ravetest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The statuses of the server's transactions
+const (
+	statusPendingValidation = "success-pending-validation"
+	statusPending           = "pending"
+	statusPendingCapture    = "pending-capture"
+	statusSuccessful        = "successful"
+	statusVoided            = "voided"
+	statusRefunded          = "refunded"
+)
+
+// Transaction is a charge made on the server
+type Transaction struct {
+	ID          int
+	FlwRef      string
+	TxRef       string
+	PaymentType string
+	ChargeType  string
+	Email       string
+	Amount      float64
+	Currency    string
+	// AuthModel is the auth model the charge used, and AuthURL where the card holder completes 3DS for redirected charges
+	AuthModel string
+	AuthURL   string
+	// Status is one of success-pending-validation, pending, pending-capture, successful, voided or refunded
+	Status                string
+	ChargeResponseCode    string
+	ChargeResponseMessage string
+	// CapturedAmount is the captured part of a preauth, RefundedAmount the amount refunded
+	CapturedAmount float64
+	RefundedAmount float64
+	CreatedAt      time.Time
+}
+
+// newTransaction returns a transaction for the charge payload, the caller adds it to s.txns once it's charged
+func (s *Server) newTransaction(p chargePayload, refPrefix string) *Transaction {
+	id := len(s.txns) + 1
+	return &Transaction{
+		ID:          id,
+		FlwRef:      fmt.Sprintf("%s%d%08d", refPrefix, time.Now().Unix(), id),
+		TxRef:       p.TxRef,
+		PaymentType: p.PaymentType,
+		ChargeType:  chargeType(p.ChargeType),
+		Email:       p.Email,
+		Amount:      p.Amount,
+		Currency:    p.Currency,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+func chargeType(t string) string {
+	if t == "" {
+		return "normal"
+	}
+	return t
+}
+
+// pending reports whether the transaction is waiting on the customer
+func (t *Transaction) pending() bool {
+	return t.Status == statusPendingValidation || t.Status == statusPending
+}
+
+func (t *Transaction) pendValidation(authModel string) {
+	t.AuthModel = authModel
+	t.Status = statusPendingValidation
+	t.ChargeResponseCode = "02"
+	t.ChargeResponseMessage = "Kindly enter the OTP sent to you"
+}
+
+func (t *Transaction) pendRedirect(authModel, authURL string) {
+	t.AuthModel = authModel
+	t.AuthURL = authURL + "?ref=" + t.FlwRef
+	t.Status = statusPendingValidation
+	t.ChargeResponseCode = "02"
+	t.ChargeResponseMessage = "Kindly complete the authentication on the auth url"
+}
+
+// authorize holds the amount of a preauth charge for capture
+func (t *Transaction) authorize() {
+	t.Status = statusPendingCapture
+	t.ChargeResponseCode = "00"
+	t.ChargeResponseMessage = "Approved"
+}
+
+func (t *Transaction) succeed() {
+	t.Status = statusSuccessful
+	t.ChargeResponseCode = "00"
+	t.ChargeResponseMessage = "Approved. Successful"
+}
+
+func (t *Transaction) createdAt() string {
+	return t.CreatedAt.Format("2006-01-02T15:04:05.000Z")
+}
+
+// chargeData is the transaction in the shape of charge and validation responses
+func (t *Transaction) chargeData() map[string]interface{} {
+	data := map[string]interface{}{
+		"id":                    t.ID,
+		"txRef":                 t.TxRef,
+		"flwRef":                t.FlwRef,
+		"amount":                t.Amount,
+		"charged_amount":        t.Amount,
+		"currency":              t.Currency,
+		"chargeResponseCode":    t.ChargeResponseCode,
+		"chargeResponseMessage": t.ChargeResponseMessage,
+		"authModelUsed":         t.AuthModel,
+		"authurl":               "N/A",
+		"status":                t.Status,
+		"paymentType":           t.PaymentType,
+		"charge_type":           t.ChargeType,
+		"fraud_status":          "ok",
+		"createdAt":             t.createdAt(),
+		"customer":              map[string]interface{}{"email": t.Email},
+	}
+	if t.AuthURL != "" {
+		data["authurl"] = t.AuthURL
+	}
+	if t.ChargeResponseCode == "02" && t.AuthModel == string(CardAuthPIN) {
+		data["validateInstructions"] = map[string]interface{}{
+			"instruction": "Please validate with the OTP sent to your mobile or email",
+			"valparams":   []string{"OTP"},
+		}
+	}
+	if t.PaymentType == "card" && t.Status == statusSuccessful {
+		data["chargeToken"] = map[string]interface{}{"user_token": fmt.Sprintf("%05x", t.ID), "embed_token": "flw-t0-" + strings.ToLower(t.FlwRef)}
+	}
+	return data
+}
+
+func (s *Server) verify(r *http.Request) (interface{}, error) {
+	var req struct {
+		FlwRef string `json:"flw_ref"`
+		TxRef  string `json:"tx_ref"`
+		SECKEY string `json:"SECKEY"`
+	}
+	t, err := s.lookup(r, &req, &req.SECKEY, &req.FlwRef, &req.TxRef)
+	if err != nil {
+		return nil, err
+	}
+	return success("Tx Fetched", map[string]interface{}{
+		"id":                   t.ID,
+		"tx_ref":               t.TxRef,
+		"flw_ref":              t.FlwRef,
+		"amount":               t.Amount,
+		"charged_amount":       t.Amount,
+		"transaction_currency": t.Currency,
+		"status":               t.Status,
+		"charge_type":          t.ChargeType,
+		"payment_entity":       t.PaymentType,
+		"fraud_status":         "ok",
+		"createdAt":            t.createdAt(),
+		"flwMeta":              map[string]interface{}{"chargeResponse": t.ChargeResponseCode, "chargeResponseMessage": t.ChargeResponseMessage},
+		"customer":             map[string]interface{}{"email": t.Email},
+	}), nil
+}
+
+// xrequery responds with the last attempt of the transaction if it's asked for it, otherwise with every attempt
+func (s *Server) xrequery(r *http.Request) (interface{}, error) {
+	var req struct {
+		Flwref         string `json:"flwref"`
+		Txref          string `json:"txref"`
+		SECKEY         string `json:"SECKEY"`
+		LastAttempt    string `json:"last_attempt"`
+		OnlySuccessful string `json:"only_successful"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.SECKEY != s.SecretKey {
+		return nil, errInvalidSecretKey
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var attempts []interface{}
+	for _, t := range s.txns {
+		if (req.Flwref == "" || t.FlwRef != req.Flwref) && (req.Txref == "" || t.TxRef != req.Txref) {
+			continue
+		}
+		if req.OnlySuccessful == "1" && t.Status != statusSuccessful {
+			continue
+		}
+		attempts = append(attempts, t.xrequeryData())
+	}
+	if len(attempts) == 0 {
+		return nil, errNoTransaction
+	}
+	if req.LastAttempt == "1" {
+		return success("Tx Fetched", attempts[len(attempts)-1]), nil
+	}
+	return success("Tx Fetched", attempts), nil
+}
+
+// xrequeryData is the transaction in the shape of xrequery responses
+func (t *Transaction) xrequeryData() map[string]interface{} {
+	return map[string]interface{}{
+		"txid":          t.ID,
+		"txref":         t.TxRef,
+		"flwref":        t.FlwRef,
+		"amount":        t.Amount,
+		"chargedamount": t.Amount,
+		"currency":      t.Currency,
+		"chargecode":    t.ChargeResponseCode,
+		"chargemessage": t.ChargeResponseMessage,
+		"authmodel":     t.AuthModel,
+		"authurl":       t.AuthURL,
+		"status":        t.Status,
+		"paymenttype":   t.PaymentType,
+		"fraudstatus":   "ok",
+		"chargetype":    t.ChargeType,
+		"custemail":     t.Email,
+		"created":       t.createdAt(),
+	}
+}
+
+// lookup decodes the request into req and finds the transaction by the decoded refs after checking the decoded secret key
+func (s *Server) lookup(r *http.Request, req interface{}, secKey, flwRef, txRef *string) (Transaction, error) {
+	if err := decode(r, req); err != nil {
+		return Transaction{}, err
+	}
+	if *secKey != s.SecretKey {
+		return Transaction{}, errInvalidSecretKey
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.findTransaction(*flwRef, *txRef)
+	if t == nil {
+		return Transaction{}, errNoTransaction
+	}
+	return *t, nil
+}
+
+func (s *Server) refund(r *http.Request) (interface{}, error) {
+	var req struct {
+		SECKEY string `json:"SECKEY"`
+		Ref    string `json:"ref"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.SECKEY != s.SecretKey {
+		return nil, errInvalidSecretKey
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.findTransaction(req.Ref, "")
+	switch {
+	case t == nil:
+		return nil, errNoTransaction
+	case t.Status == statusRefunded:
+		return nil, raveError("Transaction has been refunded")
+	case t.Status != statusSuccessful:
+		return nil, raveError("Only successful transactions can be refunded")
+	}
+	t.RefundedAmount = t.Amount
+	if t.CapturedAmount > 0 {
+		t.RefundedAmount = t.CapturedAmount
+	}
+	t.Status = statusRefunded
+	return success("Refunded", map[string]interface{}{
+		"id":             t.ID,
+		"TransactionId":  t.ID,
+		"FlwRef":         t.FlwRef,
+		"AmountRefunded": t.RefundedAmount,
+		"status":         "completed",
+		"createdAt":      time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
+	}), nil
+}
+
+func (s *Server) capture(r *http.Request) (interface{}, error) {
+	var req struct {
+		SECKEY string   `json:"SECKEY"`
+		FlwRef string   `json:"flwRef"`
+		Amount *float64 `json:"amount"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.SECKEY != s.SecretKey {
+		return nil, errInvalidSecretKey
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.findTransaction(req.FlwRef, "")
+	switch {
+	case t == nil:
+		return nil, errNoTransaction
+	case t.Status != statusPendingCapture:
+		return nil, raveError("Transaction is not pending capture")
+	}
+	amount := t.Amount
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+	if amount <= 0 || amount > t.Amount {
+		return nil, raveError("Capture amount must be positive and not more than the authorized amount")
+	}
+	t.CapturedAmount = amount
+	t.succeed()
+
+	data := t.chargeData()
+	data["amount"], data["charged_amount"] = amount, amount
+	return success("Capture complete", data), nil
+}
+
+func (s *Server) refundOrVoid(r *http.Request) (interface{}, error) {
+	var req struct {
+		Action string `json:"action"`
+		Ref    string `json:"ref"`
+		SECKEY string `json:"SECKEY"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.SECKEY != s.SecretKey {
+		return nil, errInvalidSecretKey
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.findTransaction(req.Ref, "")
+	switch {
+	case t == nil:
+		return nil, errNoTransaction
+	case req.Action == "void" && t.Status == statusPendingCapture:
+		t.Status = statusVoided
+	case req.Action == "refund" && t.Status == statusSuccessful && t.ChargeType == "preauth":
+		t.RefundedAmount = t.CapturedAmount
+		t.Status = statusRefunded
+	default:
+		return nil, raveError(fmt.Sprintf("Transaction can't be %sed", req.Action))
+	}
+	message := "Refund complete"
+	if req.Action == "void" {
+		message = "Void complete"
+	}
+	return success(message, map[string]interface{}{
+		"data": map[string]interface{}{
+			"responsecode":         "00",
+			"responsemessage":      "Approved",
+			"transactionreference": t.FlwRef,
+		},
+		"status": "success",
+	}), nil
+}
+
+// round rounds the amount to 2 decimal places
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,108 @@
+package ravepay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// redirectResponseParam is the query parameter rave sends the charge in when redirecting the customer
+const redirectResponseParam = "response"
+
+// ErrUnknownRedirect is returned when a redirect's txRef doesn't match any charge
+var ErrUnknownRedirect = errors.New("ravepay: no charge matches the redirect's txRef")
+
+// ParseRedirectResponse decodes the charge rave sends back with the customer to the charge request's RedirectURL
+// after the customer authenticates the charge on their bank's page
+// The charge is only as trustworthy as the customer's browser, so it must be verified before it is given value
+func ParseRedirectResponse(r *http.Request) (*ChargeResponse, error) {
+	raw := r.FormValue(redirectResponseParam)
+	if raw == "" {
+		return &ChargeResponse{}, fmt.Errorf("RedirectResponseMissing: the request has no %s parameter", redirectResponseParam)
+	}
+
+	// the charge is sent either as is or nested in a tx object
+	payload := struct {
+		chargeResponseData
+		Tx *chargeResponseData `json:"tx"`
+	}{}
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		return &ChargeResponse{}, err
+	}
+
+	resp := &ChargeResponse{Data: payload.chargeResponseData}
+	if payload.Tx != nil {
+		resp.Data = *payload.Tx
+	}
+	return resp, nil
+}
+
+// RedirectHandler is an http.Handler for the redirect url rave sends customers back to after a card charge's auth url
+// It decodes the charge in the request, verifies it with rave using the checklist for its txRef
+// and hands it to OnSuccess or OnFailure to respond to the customer
+type RedirectHandler struct {
+	// Checklist returns the checklist for verifying the charge with the txRef e.g with the amount of the order for the txRef
+	// The checklist's FlwRef is set from the redirect if empty
+	// A nil checklist fails the charge with ErrUnknownRedirect, as do redirects without a txRef and a handler without Checklist
+	Checklist func(r *http.Request, txRef string) *TxnVerificationChecklist
+
+	// OnSuccess is called with the charges that pass the verification, it responds with 200 if nil
+	OnSuccess func(w http.ResponseWriter, r *http.Request, resp *ChargeResponse, verification *TxnVerificationResponse)
+	// OnFailure is called with the charges that can't be decoded, correlated or verified, it responds with 400 if nil
+	OnFailure func(w http.ResponseWriter, r *http.Request, resp *ChargeResponse, errs []error)
+
+	// Client is used for verifying charges, the default client is used if nil
+	Client *Client
+}
+
+func (h *RedirectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp, err := ParseRedirectResponse(r)
+	if err != nil {
+		h.failure(w, r, resp, []error{err})
+		return
+	}
+
+	txRef := resp.Data.TxRef
+	if txRef == "" || h.Checklist == nil {
+		h.failure(w, r, resp, []error{ErrUnknownRedirect})
+		return
+	}
+	checklist := h.Checklist(r, txRef)
+	if checklist == nil {
+		h.failure(w, r, resp, []error{ErrUnknownRedirect})
+		return
+	}
+	if checklist.FlwRef == "" {
+		checklist.FlwRef = resp.Data.FlwRef
+	}
+
+	c := h.Client
+	if c == nil {
+		c = defaultClient()
+	}
+
+	verification, errs := c.VerifyTransactionContext(r.Context(), checklist)
+	// the flwRef comes from the customer's browser, so make sure it is for the charge with the txRef
+	if len(errs) == 0 && verification.Data.TxRef != txRef {
+		errs = append(errs, fmt.Errorf("RedirectTxRefMismatch: verified transaction has txRef %s, want %s", verification.Data.TxRef, txRef))
+	}
+	if len(errs) != 0 {
+		h.failure(w, r, resp, errs)
+		return
+	}
+
+	if h.OnSuccess == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	h.OnSuccess(w, r, resp, verification)
+}
+
+func (h *RedirectHandler) failure(w http.ResponseWriter, r *http.Request, resp *ChargeResponse, errs []error) {
+	if h.OnFailure == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	h.OnFailure(w, r, resp, errs)
+}
@@ -0,0 +1,156 @@
+package ravepay
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestParseRedirectResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantTxRef  string
+		wantFlwRef string
+		wantErr    bool
+	}{
+		{
+			name:       "decodes the charge",
+			query:      url.Values{"response": {redirectChargeResponse}}.Encode(),
+			wantTxRef:  "5f06e536-e981-4f52-9e0b-336600798dc5",
+			wantFlwRef: "FLW-MOCK-09805abc71c5eebf80bb899183475fe3",
+		},
+		{
+			name:       "decodes the charge nested in a tx object",
+			query:      url.Values{"response": {`{"tx":` + redirectChargeResponse + `}`}}.Encode(),
+			wantTxRef:  "5f06e536-e981-4f52-9e0b-336600798dc5",
+			wantFlwRef: "FLW-MOCK-09805abc71c5eebf80bb899183475fe3",
+		},
+		{
+			name:    "returns an error without the response parameter",
+			query:   "",
+			wantErr: true,
+		},
+		{
+			name:    "returns an error for malformed responses",
+			query:   url.Values{"response": {`{"txRef":`}}.Encode(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/rave/redirect?"+tt.query, nil)
+			got, err := ParseRedirectResponse(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRedirectResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Data.TxRef != tt.wantTxRef || got.Data.FlwRef != tt.wantFlwRef {
+				t.Errorf("ParseRedirectResponse() = %+v", got.Data)
+			}
+		})
+	}
+}
+
+func TestRedirectHandler_ServeHTTP(t *testing.T) {
+	verifyHandler := &testServer{resp: []byte(successfulCardVerifyPaymentResponse)}
+	server := httptest.NewServer(verifyHandler)
+	defer server.Close()
+
+	var succeeded *ChargeResponse
+	var failures []error
+	var checked []string
+	handler := &RedirectHandler{
+		Checklist: func(r *http.Request, txRef string) *TxnVerificationChecklist {
+			checked = append(checked, txRef)
+			orders := map[string]int{"5f06e536-e981-4f52-9e0b-336600798dc5": 300, "another-order": 300, "pricier-order": 500}
+			amount, ok := orders[txRef]
+			if !ok {
+				return nil
+			}
+			return &TxnVerificationChecklist{Amount: NewMoney(int64(amount)*100, "NGN"), TransactionCurrency: "NGN", VerificationURL: server.URL}
+		},
+		OnSuccess: func(w http.ResponseWriter, r *http.Request, resp *ChargeResponse, verification *TxnVerificationResponse) {
+			succeeded = resp
+			http.Redirect(w, r, "/orders/"+resp.Data.TxRef, http.StatusFound)
+		},
+		OnFailure: func(w http.ResponseWriter, r *http.Request, resp *ChargeResponse, errs []error) {
+			failures = errs
+			w.WriteHeader(http.StatusPaymentRequired)
+		},
+	}
+
+	tests := []struct {
+		name        string
+		response    string
+		wantStatus  int
+		wantErr     error
+		wantSuccess bool
+	}{
+		{
+			name:        "verifies the charge and calls OnSuccess",
+			response:    redirectChargeResponse,
+			wantStatus:  http.StatusFound,
+			wantSuccess: true,
+		},
+		{
+			name:       "fails charges for unknown txRefs",
+			response:   `{"txRef":"unknown-order","flwRef":"FLW-MOCK-09805abc71c5eebf80bb899183475fe3"}`,
+			wantStatus: http.StatusPaymentRequired,
+			wantErr:    ErrUnknownRedirect,
+		},
+		{
+			name:       "fails charges without a txRef",
+			response:   `{"flwRef":"FLW-MOCK-09805abc71c5eebf80bb899183475fe3"}`,
+			wantStatus: http.StatusPaymentRequired,
+			wantErr:    ErrUnknownRedirect,
+		},
+		{
+			name:       "fails charges whose verified txRef is for another order",
+			response:   `{"txRef":"another-order","flwRef":"FLW-MOCK-09805abc71c5eebf80bb899183475fe3"}`,
+			wantStatus: http.StatusPaymentRequired,
+		},
+		{
+			name:       "fails charges that don't pass the verification",
+			response:   `{"txRef":"pricier-order","flwRef":"FLW-MOCK-09805abc71c5eebf80bb899183475fe3"}`,
+			wantStatus: http.StatusPaymentRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			succeeded, failures, checked = nil, nil, nil
+
+			req := httptest.NewRequest("GET", "/rave/redirect?"+url.Values{"response": {tt.response}}.Encode(), nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("RedirectHandler.ServeHTTP() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if (succeeded != nil) != tt.wantSuccess || (len(failures) == 0) != tt.wantSuccess {
+				t.Errorf("RedirectHandler.ServeHTTP() succeeded with %v, failed with %v", succeeded, failures)
+			}
+			if tt.wantErr != nil && (len(failures) != 1 || !errors.Is(failures[0], tt.wantErr)) {
+				t.Errorf("RedirectHandler.ServeHTTP() failed with %v, want %v", failures, tt.wantErr)
+			}
+			for _, txRef := range checked {
+				if txRef == "" {
+					t.Errorf("RedirectHandler.ServeHTTP() asked for the checklist of an empty txRef")
+				}
+			}
+		})
+	}
+}
+
+func TestRedirectHandler_ServeHTTP_noChecklist(t *testing.T) {
+	req := httptest.NewRequest("GET", "/rave/redirect?"+url.Values{"response": {redirectChargeResponse}}.Encode(), nil)
+	w := httptest.NewRecorder()
+	(&RedirectHandler{}).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("RedirectHandler.ServeHTTP() status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+var redirectChargeResponse = `{"id":56673,"txRef":"5f06e536-e981-4f52-9e0b-336600798dc5","flwRef":"FLW-MOCK-09805abc71c5eebf80bb899183475fe3","amount":300,"charged_amount":300,"chargeResponseCode":"00","chargeResponseMessage":"Approved","authModelUsed":"VBVSECURECODE","currency":"NGN","status":"successful","paymentType":"card"}`
@@ -1,5 +1,7 @@
 package ravepay
 
+import "context"
+
 // FIXME: Done to enable testing
 var rTxnURL = buildURL(refundTxnURL)
 
@@ -7,7 +9,7 @@ var rTxnURL = buildURL(refundTxnURL)
 type RefundTxnResponse struct {
 	Data struct {
 		AccountID      int    `json:"AccountId"`
-		AmountRefunded int    `json:"AmountRefunded"`
+		AmountRefunded Money  `json:"AmountRefunded"`
 		FlwRef         string `json:"FlwRef"`
 		TransactionID  int    `json:"TransactionId"`
 		CreatedAt      string `json:"createdAt"`
@@ -23,12 +25,34 @@ type RefundTxnResponse struct {
 // Refund makes a refund request for txn with the given ref
 // it returns rave's response and any error that occurs
 func Refund(ref string) (*RefundTxnResponse, error) {
+	return RefundContext(context.Background(), ref)
+}
+
+// RefundContext is like Refund but the request is bound to the given context
+func RefundContext(ctx context.Context, ref string) (*RefundTxnResponse, error) {
+	return defaultClient().refund(ctx, rTxnURL, ref)
+}
+
+// Refund makes a refund request for txn with the given ref
+// it returns rave's response and any error that occurs
+func (c *Client) Refund(ref string) (*RefundTxnResponse, error) {
+	return c.RefundContext(context.Background(), ref)
+}
+
+// RefundContext is like Refund but the request is bound to the given context
+func (c *Client) RefundContext(ctx context.Context, ref string) (*RefundTxnResponse, error) {
+	return c.refund(ctx, c.buildURL(refundTxnURL), ref)
+}
+
+func (c *Client) refund(ctx context.Context, url, ref string) (*RefundTxnResponse, error) {
 	resp := &RefundTxnResponse{}
 	payload := struct {
 		SECKEY string `json:"SECKEY"`
 		FlwRef string `json:"ref"`
-	}{SecretKey, ref}
+	}{c.SecretKey, ref}
 
-	err := sendRequestAndParseResponse("POST", rTxnURL, payload, resp)
+	// the refund is never re-sent, rave's lookup doesn't show whether a transaction was refunded
+	// so a refund whose response was lost can't be told from one that didn't go through
+	err := c.sendRequestAndParseResponse(ctx, "POST", url, payload, resp)
 	return resp, err
 }
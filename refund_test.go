@@ -43,7 +43,7 @@ func TestRefund(t *testing.T) {
 				Status:  "error",
 				Message: "No transaction found",
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
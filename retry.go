@@ -0,0 +1,180 @@
+package ravepay
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how a client retries requests that fail intermittently
+// Requests that are safe to repeat (verification, banks, fees and forex) are retried automatically
+// Charges and preauth captures are only re-sent after a verification lookup
+// shows the original request didn't go through, so a retry never double charges a customer
+// Refunds are never re-sent as the lookup doesn't show whether a transaction was refunded
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request including the first
+	// values less than 2 disable retries
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between retries
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after every retry, it defaults to 2
+	Multiplier float64
+	// RetryOn is the set of http status codes that are retried
+	// transport errors like connection resets are always retried
+	RetryOn []int
+}
+
+// DefaultRetryPolicy returns a policy that makes up to 3 attempts with backoff between 500ms and 5s
+// retrying on rate limiting and rave's gateway errors
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		RetryOn:        []int{429, 500, 502, 503, 504},
+	}
+}
+
+// WithRetryPolicy sets the retry policy used by the client
+// Clients don't retry failed requests unless given a policy
+func WithRetryPolicy(p *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = p
+	}
+}
+
+// backoff returns the wait before the given retry, starting from 1
+// It is the exponential backoff for the retry with equal jitter applied
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	half := time.Duration(d / 2)
+	if half <= 0 {
+		return time.Duration(d)
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryable reports whether the failed request that returned the given error should be retried
+func (p *RetryPolicy) retryable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.Err != nil {
+		return !errors.Is(apiErr.Err, context.Canceled) && !errors.Is(apiErr.Err, context.DeadlineExceeded)
+	}
+
+	for _, code := range p.RetryOn {
+		if apiErr.HTTPStatus == code {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetries calls send until it succeeds, fails with an error the client's policy doesn't retry
+// or the policy's attempts are exhausted
+// resend, if not nil, is consulted before every retry and stops the retries when it returns false
+func (c *Client) withRetries(ctx context.Context, send func(context.Context) error, resend func(context.Context) bool) error {
+	err := send(ctx)
+
+	p := c.retryPolicy
+	if p == nil {
+		return err
+	}
+
+	for retry := 1; retry < p.MaxAttempts && err != nil && p.retryable(err); retry++ {
+		timer := time.NewTimer(p.backoff(retry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		if resend != nil && !resend(ctx) {
+			return err
+		}
+		err = send(ctx)
+	}
+
+	return err
+}
+
+// lookupTransaction queries rave's xrequery endpoint once for the last attempt of the transaction with the given refs
+// it is used to tell whether a failed charge or capture went through before re-sending it
+func (c *Client) lookupTransaction(ctx context.Context, flwRef, txRef string) (*XRQTxnVerificationResponse, error) {
+	payload := &TxnVerificationChecklist{
+		Flwref:      flwRef,
+		Txref:       txRef,
+		LastAttempt: "1",
+		SECKEY:      c.SecretKey,
+	}
+
+	resp := &XRQTxnVerificationResponse{}
+	err := c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(txnVerificationRequeryURL), payload, resp)
+	return resp, err
+}
+
+// isNotFound reports whether the lookup error is rave reporting that there's no such transaction
+// Only a 404 or rave's "No transaction found" message count, any other error leaves it unknown
+// whether the original request went through
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Err != nil {
+		return false
+	}
+	return apiErr.HTTPStatus == http.StatusNotFound || strings.Contains(strings.ToLower(apiErr.Message), "no transaction found")
+}
+
+// chargeResponseFromLookup builds a charge response from the xrequery lookup of a charge
+// it allows a charge whose response was lost in transport to carry on as if the response was received
+func chargeResponseFromLookup(l *XRQTxnVerificationResponse) ChargeResponse {
+	return ChargeResponse{
+		Status:  l.Status,
+		Message: l.Message,
+		Data: chargeResponseData{
+			Amount:                l.Data.Amount,
+			AuthModelUsed:         l.Data.Authmodel,
+			Authurl:               l.Data.Authurl,
+			ChargeResponseCode:    l.Data.Chargecode,
+			ChargeResponseMessage: l.Data.Chargemessage,
+			ChargeType:            l.Data.Chargetype,
+			CreatedAt:             l.Data.Created,
+			Currency:              l.Data.Currency,
+			CustomerID:            l.Data.Customerid,
+			Cycle:                 l.Data.Cycle,
+			DeviceFingerprint:     l.Data.Devicefingerprint,
+			FlwRef:                l.Data.Flwref,
+			FraudStatus:           l.Data.Fraudstatus,
+			ID:                    l.Data.Txid,
+			IP:                    l.Data.IP,
+			Narration:             l.Data.Narration,
+			OrderRef:              l.Data.Orderref,
+			PaymentID:             l.Data.Paymentid,
+			PaymentPlan:           l.Data.Paymentplan,
+			PaymentType:           l.Data.Paymenttype,
+			Status:                l.Data.Status,
+			TxRef:                 l.Data.Txref,
+			Vbvrespcode:           l.Data.Vbvcode,
+			Vbvrespmessage:        l.Data.Vbvmessage,
+		},
+	}
+}
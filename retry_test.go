@@ -0,0 +1,201 @@
+package ravepay
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	tests := []struct {
+		retry int
+		max   time.Duration
+	}{
+		{retry: 1, max: 100 * time.Millisecond},
+		{retry: 2, max: 200 * time.Millisecond},
+		{retry: 3, max: 300 * time.Millisecond},
+		{retry: 4, max: 300 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if got := p.backoff(tt.retry); got < tt.max/2 || got > tt.max {
+				t.Errorf("RetryPolicy.backoff(%d) = %v, want between %v and %v", tt.retry, got, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+func TestRetryPolicy_retryable(t *testing.T) {
+	p := DefaultRetryPolicy()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "retries transport errors", err: &APIError{Err: errors.New("connection reset by peer")}, want: true},
+		{name: "retries statuses in the retry set", err: &APIError{HTTPStatus: 502}, want: true},
+		{name: "doesn't retry other statuses", err: &APIError{HTTPStatus: 400}, want: false},
+		{name: "doesn't retry rave errors", err: &APIError{HTTPStatus: 200, Status: "error"}, want: false},
+		{name: "doesn't retry other errors", err: errors.New("some error"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.retryable(tt.err); got != tt.want {
+				t.Errorf("RetryPolicy.retryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "rave's no transaction found message", err: &APIError{HTTPStatus: 200, Status: "error", Message: "No transaction found"}, want: true},
+		{name: "a 404", err: &APIError{HTTPStatus: http.StatusNotFound, Status: "error"}, want: true},
+		{name: "an unclassified rave error", err: &APIError{HTTPStatus: 200, Status: "error", Message: "Unable to fetch transaction"}, want: false},
+		{name: "a bad request", err: &APIError{HTTPStatus: http.StatusBadRequest, Status: "error", Message: "txref is required"}, want: false},
+		{name: "a transport error", err: &APIError{Err: errors.New("connection reset by peer")}, want: false},
+		{name: "another error", err: errors.New("some error"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNotFound(tt.err); got != tt.want {
+				t.Errorf("isNotFound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type retryTestServer struct {
+	chargeAttempts, lookups int
+	chargeFailures          int
+	lookupResp              string
+	resp                    string
+}
+
+func (ts *retryTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == txnVerificationRequeryURL {
+		ts.lookups++
+		w.Write([]byte(ts.lookupResp))
+		return
+	}
+
+	ts.chargeAttempts++
+	if ts.chargeAttempts <= ts.chargeFailures {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	w.Write([]byte(ts.resp))
+}
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryOn: []int{502}}
+}
+
+func TestClient_retriesSafeRequests(t *testing.T) {
+	handler := &retryTestServer{chargeFailures: 2, resp: getFeeResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+	resp, err := c.GetFee(&GetFeeRequest{Amount: NewMoney(100000, "NGN"), Currency: "NGN"})
+	if err != nil {
+		t.Fatalf("Client.GetFee() error = %v", err)
+	}
+	if resp.Status != "success" || handler.chargeAttempts != 3 {
+		t.Errorf("Client.GetFee() = %v after %d attempts, want success after 3", resp.Status, handler.chargeAttempts)
+	}
+
+	handler.chargeAttempts = 0
+	c = NewClient(WithBaseURL(server.URL))
+	if _, err := c.GetFee(&GetFeeRequest{Amount: NewMoney(100000, "NGN"), Currency: "NGN"}); err == nil || handler.chargeAttempts != 1 {
+		t.Errorf("Client.GetFee() without retry policy made %d attempts, want 1", handler.chargeAttempts)
+	}
+}
+
+func TestClient_retriesChargeSafely(t *testing.T) {
+	tests := []struct {
+		name             string
+		txRef            string
+		lookupResp       string
+		wantErr          bool
+		wantAttempts     int
+		wantLookups      int
+		wantFlwRef       string
+		wantResponseCode string
+	}{
+		{
+			name:             "recovers the charge from the lookup if rave has the transaction",
+			txRef:            "OH-AAED44",
+			lookupResp:       xRQSuccessfulVerificationResponse,
+			wantAttempts:     1,
+			wantLookups:      1,
+			wantFlwRef:       "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88",
+			wantResponseCode: "00",
+		},
+		{
+			name:             "re-sends the charge if rave has no record of the transaction",
+			txRef:            "MXX-ASC-4578",
+			lookupResp:       noTransactionFoundVerifyPaymentResponse,
+			wantAttempts:     2,
+			wantLookups:      1,
+			wantFlwRef:       "FLW-MOCK-0cd9a725cf2ad31303299840f5a0896a",
+			wantResponseCode: "02",
+		},
+		{
+			name:         "doesn't re-send the charge if the lookup fails for another reason",
+			txRef:        "MXX-ASC-4578",
+			lookupResp:   `{"status":"error","message":"Unable to fetch transaction"}`,
+			wantErr:      true,
+			wantAttempts: 1,
+			wantLookups:  1,
+		},
+		{
+			name:         "rejects the charge without a txRef to look up before sending it",
+			wantErr:      true,
+			wantAttempts: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &retryTestServer{chargeFailures: 1, lookupResp: tt.lookupResp, resp: successfulCardChargeResponse}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			c := NewClient(WithKeys(PublicKey, SecretKey), WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+			got, err := c.Charge(&ChargeRequest{TxRef: tt.txRef, Amount: NewMoney(30000, "NGN"), Email: "tester@flutter.co"}, &Card{CardNo: "5438898014560229", Expirymonth: "09", Expiryyear: testExpiryYear, Cvv: "789", ChargeCardURL: server.URL})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.Charge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if handler.chargeAttempts != tt.wantAttempts || handler.lookups != tt.wantLookups {
+				t.Errorf("Client.Charge() made %d charge attempts and %d lookups, want %d and %d", handler.chargeAttempts, handler.lookups, tt.wantAttempts, tt.wantLookups)
+			}
+			if got.Data.FlwRef != tt.wantFlwRef || got.Data.ChargeResponseCode != tt.wantResponseCode {
+				t.Errorf("Client.Charge() data = %+v, want flwRef %s", got.Data, tt.wantFlwRef)
+			}
+		})
+	}
+}
+
+func TestClient_doesntRetryRefunds(t *testing.T) {
+	handler := &retryTestServer{chargeFailures: 1, lookupResp: xRQSuccessfulVerificationResponse, resp: successfulRefundTxnResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// the lookup still shows the transaction successful after it's refunded, so it can't tell whether to re-send
+	c := NewClient(WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+	if _, err := c.Refund("FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88"); err == nil {
+		t.Errorf("Client.Refund() error = nil, want the error of the failed attempt")
+	}
+	if handler.chargeAttempts != 1 || handler.lookups != 0 {
+		t.Errorf("Client.Refund() made %d attempts and %d lookups, want 1 and 0", handler.chargeAttempts, handler.lookups)
+	}
+}
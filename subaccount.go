@@ -0,0 +1,317 @@
+package ravepay
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Split types for subaccounts and charge splits
+// A percentage split value is a fraction of the charge e.g 0.1 for 10%
+// A flat split value is an amount in the charge currency
+const (
+	SplitTypePercentage = "percentage"
+	SplitTypeFlat       = "flat"
+)
+
+// Subaccount is a type of rave subaccount resource, a settlement account a charge can be split to
+type Subaccount struct {
+	AccountBank   string  `json:"account_bank"`
+	AccountNumber string  `json:"account_number"`
+	BankName      string  `json:"bank_name"`
+	BusinessName  string  `json:"business_name"`
+	Country       string  `json:"country"`
+	DateCreated   string  `json:"date_created"`
+	FullName      string  `json:"fullname"`
+	ID            int     `json:"id"`
+	SplitRatio    float64 `json:"split_ratio"`
+	SplitType     string  `json:"split_type"`
+	SplitValue    float64 `json:"split_value"`
+	// SubaccountID is the id the subaccount is referenced by in charges e.g RS_A59429B9C94C5A3C3B5FA6F3B8B8D6F3
+	SubaccountID string `json:"subaccount_id"`
+}
+
+// SubaccountRequest holds the information for creating or editing a subaccount
+// https://developer.flutterwave.com/v2.0/reference#create-subaccount
+// The split is the merchant's commission on charges split to the subaccount
+type SubaccountRequest struct {
+	AccountBank           string  `json:"account_bank,omitempty"`
+	AccountNumber         string  `json:"account_number,omitempty"`
+	BusinessName          string  `json:"business_name,omitempty"`
+	BusinessEmail         string  `json:"business_email,omitempty"`
+	BusinessContact       string  `json:"business_contact,omitempty"`
+	BusinessContactMobile string  `json:"business_contact_mobile,omitempty"`
+	BusinessMobile        string  `json:"business_mobile,omitempty"`
+	Country               string  `json:"country,omitempty"`
+	SplitType             string  `json:"split_type,omitempty"`
+	SplitValue            float64 `json:"split_value,omitempty"`
+	SECKEY                string  `json:"seckey"`
+}
+
+// SubaccountSplit directs a part of a charge to a subaccount
+// https://developer.flutterwave.com/v2.0/docs/split-payment
+type SubaccountSplit struct {
+	// ID is the subaccount's SubaccountID
+	ID string `json:"id"`
+	// TransactionSplitRatio is the subaccount's share of the charge relative to the other subaccounts in the charge
+	TransactionSplitRatio float64 `json:"transaction_split_ratio,omitempty"`
+	// TransactionChargeType and TransactionCharge override the merchant's commission set on the subaccount for this charge
+	TransactionChargeType string  `json:"transaction_charge_type,omitempty"`
+	TransactionCharge     float64 `json:"transaction_charge,omitempty"`
+}
+
+// SubaccountResponse is a type of rave response for a single subaccount
+type SubaccountResponse struct {
+	Data    Subaccount `json:"data"`
+	Message string     `json:"message"`
+	Status  string     `json:"status"`
+}
+
+// SubaccountListResponse is a type of rave response for listing subaccounts
+type SubaccountListResponse struct {
+	Data struct {
+		PageInfo    PageInfo     `json:"page_info"`
+		Subaccounts []Subaccount `json:"subaccounts"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// validateSplit checks the split value is valid for the split type
+func validateSplit(field, splitType string, value float64) error {
+	switch splitType {
+	case SplitTypePercentage:
+		if value < 0 || value > 1 {
+			return &ValidationError{Field: field, Message: "percentage split must be between 0 and 1"}
+		}
+	case SplitTypeFlat:
+		if value < 0 {
+			return &ValidationError{Field: field, Message: "flat split must not be negative"}
+		}
+	default:
+		return &ValidationError{Field: field, Message: fmt.Sprintf("unknown split type %q", splitType)}
+	}
+	return nil
+}
+
+// partsPerMillion is the precision percentage splits are summed in so they don't pick up float rounding errors
+const partsPerMillion = 1000000
+
+// validateSplits checks the charge splits are complete and together they don't exceed the charge amount
+// i.e the percentage splits don't add up to more than 1, and the flat splits and the percentage splits' share of the amount
+// don't add up to more than the amount
+func validateSplits(amount Money, splits []SubaccountSplit) error {
+	var flat, percentage int64
+	for i, split := range splits {
+		field := fmt.Sprintf("subaccounts[%d]", i)
+		if split.ID == "" {
+			return &ValidationError{Field: field + ".id", Message: "is required"}
+		}
+		if split.TransactionSplitRatio < 0 {
+			return &ValidationError{Field: field + ".transaction_split_ratio", Message: "must not be negative"}
+		}
+		if split.TransactionChargeType == "" {
+			if split.TransactionCharge != 0 {
+				return &ValidationError{Field: field + ".transaction_charge_type", Message: "is required with a transaction charge"}
+			}
+			continue
+		}
+
+		if err := validateSplit(field+".transaction_charge", split.TransactionChargeType, split.TransactionCharge); err != nil {
+			return err
+		}
+		value := strconv.FormatFloat(split.TransactionCharge, 'f', -1, 64)
+		switch split.TransactionChargeType {
+		case SplitTypeFlat:
+			minor, _ := parseMinorUnits(value, minorUnitsPer(amount.Currency))
+			flat += minor
+		case SplitTypePercentage:
+			ppm, _ := parseMinorUnits(value, partsPerMillion)
+			percentage += ppm
+		}
+	}
+
+	if percentage > partsPerMillion {
+		return &ValidationError{Field: "subaccounts", Message: fmt.Sprintf("percentage splits of %s add up to more than 1", strconv.FormatFloat(float64(percentage)/partsPerMillion, 'f', -1, 64))}
+	}
+	share := (percentage*amount.Minor + partsPerMillion/2) / partsPerMillion
+	if flat+share > 0 && flat+share > amount.Minor {
+		if share == 0 {
+			return &ValidationError{Field: "subaccounts", Message: fmt.Sprintf("flat splits of %s exceed the charge amount of %s", NewMoney(flat, amount.Currency), amount)}
+		}
+		return &ValidationError{Field: "subaccounts", Message: fmt.Sprintf("flat splits of %s and percentage splits of %s exceed the charge amount of %s", NewMoney(flat, amount.Currency), NewMoney(share, amount.Currency), amount)}
+	}
+	return nil
+}
+
+// CreateSubaccount makes a request to create the subaccount
+// It sets the secret key on the request if empty
+func CreateSubaccount(r *SubaccountRequest) (*SubaccountResponse, error) {
+	return CreateSubaccountContext(context.Background(), r)
+}
+
+// CreateSubaccountContext is like CreateSubaccount but the request is bound to the given context
+func CreateSubaccountContext(ctx context.Context, r *SubaccountRequest) (*SubaccountResponse, error) {
+	return defaultClient().CreateSubaccountContext(ctx, r)
+}
+
+// CreateSubaccount makes a request to create the subaccount
+// It sets the secret key on the request if empty
+func (c *Client) CreateSubaccount(r *SubaccountRequest) (*SubaccountResponse, error) {
+	return c.CreateSubaccountContext(context.Background(), r)
+}
+
+// CreateSubaccountContext is like CreateSubaccount but the request is bound to the given context
+func (c *Client) CreateSubaccountContext(ctx context.Context, r *SubaccountRequest) (*SubaccountResponse, error) {
+	resp := &SubaccountResponse{}
+	required := []struct{ field, value string }{
+		{"account_bank", r.AccountBank},
+		{"account_number", r.AccountNumber},
+		{"business_name", r.BusinessName},
+	}
+	for _, f := range required {
+		if f.value == "" {
+			return resp, &ValidationError{Field: f.field, Message: "is required"}
+		}
+	}
+	if err := validateSplit("split_value", r.SplitType, r.SplitValue); err != nil {
+		return resp, err
+	}
+
+	if r.SECKEY == "" {
+		r.SECKEY = c.SecretKey
+	}
+
+	err := c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(createSubaccountURL), r, resp)
+	return resp, err
+}
+
+// ListSubaccounts returns the given page of the merchant's subaccounts, pages start from 1
+func ListSubaccounts(page int) (*SubaccountListResponse, error) {
+	return ListSubaccountsContext(context.Background(), page)
+}
+
+// ListSubaccountsContext is like ListSubaccounts but the request is bound to the given context
+func ListSubaccountsContext(ctx context.Context, page int) (*SubaccountListResponse, error) {
+	return defaultClient().ListSubaccountsContext(ctx, page)
+}
+
+// ListSubaccounts returns the given page of the merchant's subaccounts, pages start from 1
+func (c *Client) ListSubaccounts(page int) (*SubaccountListResponse, error) {
+	return c.ListSubaccountsContext(context.Background(), page)
+}
+
+// ListSubaccountsContext is like ListSubaccounts but the request is bound to the given context
+func (c *Client) ListSubaccountsContext(ctx context.Context, page int) (*SubaccountListResponse, error) {
+	query := url.Values{"seckey": {c.SecretKey}}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+
+	resp := &SubaccountListResponse{}
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = SubaccountListResponse{}
+		return c.sendRequestAndParseResponse(ctx, "GET", c.buildURL(subaccountsURL)+"?"+query.Encode(), nil, resp)
+	}, nil)
+	return resp, err
+}
+
+// GetSubaccount returns the subaccount with the given id
+// id is either the subaccount's ID or SubaccountID
+func GetSubaccount(id string) (*SubaccountResponse, error) {
+	return GetSubaccountContext(context.Background(), id)
+}
+
+// GetSubaccountContext is like GetSubaccount but the request is bound to the given context
+func GetSubaccountContext(ctx context.Context, id string) (*SubaccountResponse, error) {
+	return defaultClient().GetSubaccountContext(ctx, id)
+}
+
+// GetSubaccount returns the subaccount with the given id
+// id is either the subaccount's ID or SubaccountID
+func (c *Client) GetSubaccount(id string) (*SubaccountResponse, error) {
+	return c.GetSubaccountContext(context.Background(), id)
+}
+
+// GetSubaccountContext is like GetSubaccount but the request is bound to the given context
+func (c *Client) GetSubaccountContext(ctx context.Context, id string) (*SubaccountResponse, error) {
+	query := url.Values{"seckey": {c.SecretKey}}
+	endpoint := c.buildURL(fmt.Sprintf(getSubaccountURL, url.PathEscape(id))) + "?" + query.Encode()
+
+	resp := &SubaccountResponse{}
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = SubaccountResponse{}
+		return c.sendRequestAndParseResponse(ctx, "GET", endpoint, nil, resp)
+	}, nil)
+	return resp, err
+}
+
+// EditSubaccount makes a request to update the subaccount with the given id
+// only the fields set on the request are updated
+func EditSubaccount(id string, r *SubaccountRequest) (*SubaccountResponse, error) {
+	return EditSubaccountContext(context.Background(), id, r)
+}
+
+// EditSubaccountContext is like EditSubaccount but the request is bound to the given context
+func EditSubaccountContext(ctx context.Context, id string, r *SubaccountRequest) (*SubaccountResponse, error) {
+	return defaultClient().EditSubaccountContext(ctx, id, r)
+}
+
+// EditSubaccount makes a request to update the subaccount with the given id
+// only the fields set on the request are updated
+func (c *Client) EditSubaccount(id string, r *SubaccountRequest) (*SubaccountResponse, error) {
+	return c.EditSubaccountContext(context.Background(), id, r)
+}
+
+// EditSubaccountContext is like EditSubaccount but the request is bound to the given context
+func (c *Client) EditSubaccountContext(ctx context.Context, id string, r *SubaccountRequest) (*SubaccountResponse, error) {
+	resp := &SubaccountResponse{}
+	if r.SplitType != "" {
+		if err := validateSplit("split_value", r.SplitType, r.SplitValue); err != nil {
+			return resp, err
+		}
+	}
+
+	if r.SECKEY == "" {
+		r.SECKEY = c.SecretKey
+	}
+	payload := struct {
+		ID string `json:"id"`
+		*SubaccountRequest
+	}{id, r}
+
+	// edits set the subaccount's state so they are safe to retry
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = SubaccountResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(editSubaccountURL), payload, resp)
+	}, nil)
+	return resp, err
+}
+
+// DeleteSubaccount makes a request to delete the subaccount with the given id
+func DeleteSubaccount(id string) (*SubaccountResponse, error) {
+	return DeleteSubaccountContext(context.Background(), id)
+}
+
+// DeleteSubaccountContext is like DeleteSubaccount but the request is bound to the given context
+func DeleteSubaccountContext(ctx context.Context, id string) (*SubaccountResponse, error) {
+	return defaultClient().DeleteSubaccountContext(ctx, id)
+}
+
+// DeleteSubaccount makes a request to delete the subaccount with the given id
+func (c *Client) DeleteSubaccount(id string) (*SubaccountResponse, error) {
+	return c.DeleteSubaccountContext(context.Background(), id)
+}
+
+// DeleteSubaccountContext is like DeleteSubaccount but the request is bound to the given context
+func (c *Client) DeleteSubaccountContext(ctx context.Context, id string) (*SubaccountResponse, error) {
+	payload := struct {
+		ID     string `json:"id"`
+		SECKEY string `json:"seckey"`
+	}{id, c.SecretKey}
+
+	resp := &SubaccountResponse{}
+	err := c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(deleteSubaccountURL), payload, resp)
+	return resp, err
+}
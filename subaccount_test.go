@@ -0,0 +1,246 @@
+package ravepay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func Test_validateSplits(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    Money
+		splits    []SubaccountSplit
+		wantField string
+	}{
+		{
+			name:   "accepts splits within the charge amount",
+			amount: NewMoney(100000, ""),
+			splits: []SubaccountSplit{
+				{ID: "RS_1", TransactionSplitRatio: 2, TransactionChargeType: SplitTypeFlat, TransactionCharge: 600},
+				{ID: "RS_2", TransactionSplitRatio: 1, TransactionChargeType: SplitTypePercentage, TransactionCharge: 0.1},
+				{ID: "RS_3"},
+			},
+		},
+		{
+			name:      "rejects splits without a subaccount id",
+			amount:    NewMoney(100000, ""),
+			splits:    []SubaccountSplit{{TransactionSplitRatio: 1}},
+			wantField: "subaccounts[0].id",
+		},
+		{
+			name:      "rejects percentage splits above 1",
+			amount:    NewMoney(100000, ""),
+			splits:    []SubaccountSplit{{ID: "RS_1"}, {ID: "RS_2", TransactionChargeType: SplitTypePercentage, TransactionCharge: 10}},
+			wantField: "subaccounts[1].transaction_charge",
+		},
+		{
+			name:      "rejects unknown split types",
+			amount:    NewMoney(100000, ""),
+			splits:    []SubaccountSplit{{ID: "RS_1", TransactionChargeType: "fixed", TransactionCharge: 10}},
+			wantField: "subaccounts[0].transaction_charge",
+		},
+		{
+			name:      "rejects a transaction charge without a type",
+			amount:    NewMoney(100000, ""),
+			splits:    []SubaccountSplit{{ID: "RS_1", TransactionCharge: 10}},
+			wantField: "subaccounts[0].transaction_charge_type",
+		},
+		{
+			name:   "rejects flat splits exceeding the charge amount",
+			amount: NewMoney(100000, ""),
+			splits: []SubaccountSplit{
+				{ID: "RS_1", TransactionChargeType: SplitTypeFlat, TransactionCharge: 600},
+				{ID: "RS_2", TransactionChargeType: SplitTypeFlat, TransactionCharge: 500},
+			},
+			wantField: "subaccounts",
+		},
+		{
+			name:   "accepts percentage splits adding up to 1",
+			amount: NewMoney(100000, ""),
+			splits: []SubaccountSplit{
+				{ID: "RS_1", TransactionChargeType: SplitTypePercentage, TransactionCharge: 0.1},
+				{ID: "RS_2", TransactionChargeType: SplitTypePercentage, TransactionCharge: 0.2},
+				{ID: "RS_3", TransactionChargeType: SplitTypePercentage, TransactionCharge: 0.7},
+			},
+		},
+		{
+			name:   "rejects percentage splits adding up to more than 1",
+			amount: NewMoney(100000, ""),
+			splits: []SubaccountSplit{
+				{ID: "RS_1", TransactionChargeType: SplitTypePercentage, TransactionCharge: 0.6},
+				{ID: "RS_2", TransactionChargeType: SplitTypePercentage, TransactionCharge: 0.5},
+			},
+			wantField: "subaccounts",
+		},
+		{
+			name:   "accepts flat and percentage splits adding up to the charge amount",
+			amount: NewMoney(100000, ""),
+			splits: []SubaccountSplit{
+				{ID: "RS_1", TransactionChargeType: SplitTypeFlat, TransactionCharge: 500},
+				{ID: "RS_2", TransactionChargeType: SplitTypePercentage, TransactionCharge: 0.5},
+			},
+		},
+		{
+			name:   "rejects flat and percentage splits exceeding the charge amount",
+			amount: NewMoney(100000, ""),
+			splits: []SubaccountSplit{
+				{ID: "RS_1", TransactionChargeType: SplitTypeFlat, TransactionCharge: 600},
+				{ID: "RS_2", TransactionChargeType: SplitTypePercentage, TransactionCharge: 0.5},
+			},
+			wantField: "subaccounts",
+		},
+		{
+			name:   "compares flat splits in the units of the charge currency",
+			amount: NewMoney(1000, "UGX"),
+			splits: []SubaccountSplit{
+				{ID: "RS_1", TransactionChargeType: SplitTypeFlat, TransactionCharge: 500},
+				{ID: "RS_2", TransactionChargeType: SplitTypePercentage, TransactionCharge: 0.6},
+			},
+			wantField: "subaccounts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSplits(tt.amount, tt.splits)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("validateSplits() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var vErr *ValidationError
+			if !errors.As(err, &vErr) || vErr.Field != tt.wantField {
+				t.Errorf("validateSplits() error = %v, want a ValidationError on %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestChargeable_BuildChargeRequestPayload_subaccounts(t *testing.T) {
+	splits := []SubaccountSplit{{ID: "RS_1", TransactionSplitRatio: 2}, {ID: "RS_2", TransactionChargeType: SplitTypeFlat, TransactionCharge: 100}}
+	want := []interface{}{
+		map[string]interface{}{"id": "RS_1", "transaction_split_ratio": float64(2)},
+		map[string]interface{}{"id": "RS_2", "transaction_charge_type": "flat", "transaction_charge": float64(100)},
+	}
+
+	for _, chargeable := range []Chargeable{&Card{}, &Account{}, &Mpesa{}, &MobileMoneyGH{}, &USSD{}} {
+		payload := map[string]interface{}{}
+		if err := json.Unmarshal(chargeable.BuildChargeRequestPayload(&ChargeRequest{Amount: NewMoney(100000, ""), Subaccounts: splits}), &payload); err != nil {
+			t.Fatalf("%T.BuildChargeRequestPayload() returned invalid json: %v", chargeable, err)
+		}
+		if !reflect.DeepEqual(payload["subaccounts"], want) {
+			t.Errorf("%T.BuildChargeRequestPayload() subaccounts = %v, want %v", chargeable, payload["subaccounts"], want)
+		}
+	}
+}
+
+func TestClient_Charge_rejectsInvalidSplits(t *testing.T) {
+	handler := &testServer{resp: []byte(successfulCardChargeResponse)}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"))
+	cr := &ChargeRequest{Amount: NewMoney(10000, ""), Subaccounts: []SubaccountSplit{{ID: "RS_1", TransactionChargeType: SplitTypeFlat, TransactionCharge: 500}}}
+	_, err := c.Charge(cr, &Card{ChargeCardURL: server.URL})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Client.Charge() error = %v, want a validation error", err)
+	}
+}
+
+func TestClient_CreateSubaccount(t *testing.T) {
+	handler := &transferTestServer{resp: subaccountResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+
+	_, err := c.CreateSubaccount(&SubaccountRequest{AccountBank: "044", AccountNumber: "0690000035", BusinessName: "Seller", SplitType: SplitTypePercentage, SplitValue: 5})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Client.CreateSubaccount() error = %v, want a validation error", err)
+	}
+
+	got, err := c.CreateSubaccount(&SubaccountRequest{AccountBank: "044", AccountNumber: "0690000035", BusinessName: "Seller", SplitType: SplitTypePercentage, SplitValue: 0.1})
+	if err != nil {
+		t.Fatalf("Client.CreateSubaccount() error = %v", err)
+	}
+	want := map[string]interface{}{"account_bank": "044", "account_number": "0690000035", "business_name": "Seller", "split_type": "percentage", "split_value": 0.1, "seckey": "sec-key"}
+	if handler.path != createSubaccountURL || !reflect.DeepEqual(handler.payload, want) {
+		t.Errorf("request = %s %v, want %s %v", handler.path, handler.payload, createSubaccountURL, want)
+	}
+	if got.Data.SubaccountID != "RS_A59429B9C94C5A3C3B5FA6F3B8B8D6F3" {
+		t.Errorf("Client.CreateSubaccount() = %+v", got.Data)
+	}
+}
+
+func TestClient_ListSubaccounts(t *testing.T) {
+	handler := &transferTestServer{resp: subaccountListResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.ListSubaccounts(1)
+	if err != nil {
+		t.Fatalf("Client.ListSubaccounts() error = %v", err)
+	}
+	if handler.path != subaccountsURL || handler.query["page"] != "1" || handler.query["seckey"] != "sec-key" {
+		t.Errorf("request = %s %v", handler.path, handler.query)
+	}
+	if len(got.Data.Subaccounts) != 1 || got.Data.Subaccounts[0].BusinessName != "Seller" {
+		t.Errorf("Client.ListSubaccounts() = %+v", got.Data)
+	}
+}
+
+func TestClient_manageSubaccount(t *testing.T) {
+	handler := &transferTestServer{resp: subaccountResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	id := "RS_A59429B9C94C5A3C3B5FA6F3B8B8D6F3"
+
+	tests := []struct {
+		name        string
+		call        func() (*SubaccountResponse, error)
+		wantPath    string
+		wantPayload map[string]interface{}
+	}{
+		{
+			name:     "gets the subaccount",
+			call:     func() (*SubaccountResponse, error) { return c.GetSubaccount(id) },
+			wantPath: fmt.Sprintf(getSubaccountURL, id),
+		},
+		{
+			name: "edits the subaccount",
+			call: func() (*SubaccountResponse, error) {
+				return c.EditSubaccount(id, &SubaccountRequest{BusinessName: "Seller Ltd"})
+			},
+			wantPath:    editSubaccountURL,
+			wantPayload: map[string]interface{}{"id": id, "business_name": "Seller Ltd", "seckey": "sec-key"},
+		},
+		{
+			name:        "deletes the subaccount",
+			call:        func() (*SubaccountResponse, error) { return c.DeleteSubaccount(id) },
+			wantPath:    deleteSubaccountURL,
+			wantPayload: map[string]interface{}{"id": id, "seckey": "sec-key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.call(); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if handler.path != tt.wantPath || !reflect.DeepEqual(handler.payload, tt.wantPayload) {
+				t.Errorf("request = %s %v, want %s %v", handler.path, handler.payload, tt.wantPath, tt.wantPayload)
+			}
+		})
+	}
+}
+
+var subaccountResponse = `{"status":"success","message":"SUBACCOUNT-CREATED","data":{"id":125,"account_number":"0690000035","account_bank":"044","business_name":"Seller","fullname":"Seller Account","date_created":"2018-06-20T09:41:47.000Z","meta":[],"split_ratio":1,"split_type":"percentage","split_value":0.1,"subaccount_id":"RS_A59429B9C94C5A3C3B5FA6F3B8B8D6F3","bank_name":"ACCESS BANK NIGERIA","country":"NG"}}`
+
+var subaccountListResponse = `{"status":"success","message":"SUBACCOUNTS","data":{"page_info":{"total":1,"current_page":1,"total_pages":1},"subaccounts":[{"id":125,"account_number":"0690000035","account_bank":"044","business_name":"Seller","fullname":"Seller Account","date_created":"2018-06-20T09:41:47.000Z","meta":[],"split_ratio":1,"split_type":"percentage","split_value":0.1,"subaccount_id":"RS_A59429B9C94C5A3C3B5FA6F3B8B8D6F3","bank_name":"ACCESS BANK NIGERIA","country":"NG"}]}}`
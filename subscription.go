@@ -0,0 +1,159 @@
+package ravepay
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Subscription is a type of rave subscription resource, a customer's enrolment on a payment plan
+type Subscription struct {
+	Amount      Money    `json:"amount"`
+	CancelledAt string   `json:"date_cancelled"`
+	CreatedAt   string   `json:"date_created"`
+	Customer    Customer `json:"customer"`
+	Email       string   `json:"customer_email"`
+	ID          int      `json:"id"`
+	PlanID      int      `json:"plan"`
+	Status      string   `json:"status"`
+}
+
+// SubscriptionResponse is a type of rave response for a single subscription
+type SubscriptionResponse struct {
+	Data    Subscription `json:"data"`
+	Message string       `json:"message"`
+	Status  string       `json:"status"`
+}
+
+// SubscriptionListResponse is a type of rave response for querying subscriptions
+type SubscriptionListResponse struct {
+	Data struct {
+		PageInfo      PageInfo       `json:"page_info"`
+		Subscriptions []Subscription `json:"plansubscriptions"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// errSubscriptionNotFound is returned when no subscription matches an id
+var errSubscriptionNotFound = fmt.Errorf("SubscriptionNotFound: no subscription matches the id")
+
+// ListSubscriptions returns the given page of the subscriptions on the merchant's payment plans, pages start from 1
+func ListSubscriptions(page int) (*SubscriptionListResponse, error) {
+	return ListSubscriptionsContext(context.Background(), page)
+}
+
+// ListSubscriptionsContext is like ListSubscriptions but the request is bound to the given context
+func ListSubscriptionsContext(ctx context.Context, page int) (*SubscriptionListResponse, error) {
+	return defaultClient().ListSubscriptionsContext(ctx, page)
+}
+
+// ListSubscriptions returns the given page of the subscriptions on the merchant's payment plans, pages start from 1
+func (c *Client) ListSubscriptions(page int) (*SubscriptionListResponse, error) {
+	return c.ListSubscriptionsContext(context.Background(), page)
+}
+
+// ListSubscriptionsContext is like ListSubscriptions but the request is bound to the given context
+func (c *Client) ListSubscriptionsContext(ctx context.Context, page int) (*SubscriptionListResponse, error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	return c.querySubscriptions(ctx, query)
+}
+
+// GetSubscription returns the subscription with the given id
+func GetSubscription(id int) (*SubscriptionResponse, error) {
+	return GetSubscriptionContext(context.Background(), id)
+}
+
+// GetSubscriptionContext is like GetSubscription but the request is bound to the given context
+func GetSubscriptionContext(ctx context.Context, id int) (*SubscriptionResponse, error) {
+	return defaultClient().GetSubscriptionContext(ctx, id)
+}
+
+// GetSubscription returns the subscription with the given id
+func (c *Client) GetSubscription(id int) (*SubscriptionResponse, error) {
+	return c.GetSubscriptionContext(context.Background(), id)
+}
+
+// GetSubscriptionContext is like GetSubscription but the request is bound to the given context
+func (c *Client) GetSubscriptionContext(ctx context.Context, id int) (*SubscriptionResponse, error) {
+	list, err := c.querySubscriptions(ctx, url.Values{"id": {strconv.Itoa(id)}})
+	resp := &SubscriptionResponse{Status: list.Status, Message: list.Message}
+	if err != nil {
+		return resp, err
+	}
+
+	if len(list.Data.Subscriptions) == 0 {
+		return resp, errSubscriptionNotFound
+	}
+	resp.Data = list.Data.Subscriptions[0]
+	return resp, nil
+}
+
+func (c *Client) querySubscriptions(ctx context.Context, query url.Values) (*SubscriptionListResponse, error) {
+	query.Set("seckey", c.SecretKey)
+
+	resp := &SubscriptionListResponse{}
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = SubscriptionListResponse{}
+		return c.sendRequestAndParseResponse(ctx, "GET", c.buildURL(querySubscriptionsURL)+"?"+query.Encode(), nil, resp)
+	}, nil)
+	return resp, err
+}
+
+// ActivateSubscription makes a request to activate the subscription with the given id
+func ActivateSubscription(id int) (*SubscriptionResponse, error) {
+	return ActivateSubscriptionContext(context.Background(), id)
+}
+
+// ActivateSubscriptionContext is like ActivateSubscription but the request is bound to the given context
+func ActivateSubscriptionContext(ctx context.Context, id int) (*SubscriptionResponse, error) {
+	return defaultClient().ActivateSubscriptionContext(ctx, id)
+}
+
+// ActivateSubscription makes a request to activate the subscription with the given id
+func (c *Client) ActivateSubscription(id int) (*SubscriptionResponse, error) {
+	return c.ActivateSubscriptionContext(context.Background(), id)
+}
+
+// ActivateSubscriptionContext is like ActivateSubscription but the request is bound to the given context
+func (c *Client) ActivateSubscriptionContext(ctx context.Context, id int) (*SubscriptionResponse, error) {
+	return c.updateSubscription(ctx, fmt.Sprintf(activateSubscriptionURL, id))
+}
+
+// CancelSubscription makes a request to cancel the subscription with the given id
+func CancelSubscription(id int) (*SubscriptionResponse, error) {
+	return CancelSubscriptionContext(context.Background(), id)
+}
+
+// CancelSubscriptionContext is like CancelSubscription but the request is bound to the given context
+func CancelSubscriptionContext(ctx context.Context, id int) (*SubscriptionResponse, error) {
+	return defaultClient().CancelSubscriptionContext(ctx, id)
+}
+
+// CancelSubscription makes a request to cancel the subscription with the given id
+func (c *Client) CancelSubscription(id int) (*SubscriptionResponse, error) {
+	return c.CancelSubscriptionContext(context.Background(), id)
+}
+
+// CancelSubscriptionContext is like CancelSubscription but the request is bound to the given context
+func (c *Client) CancelSubscriptionContext(ctx context.Context, id int) (*SubscriptionResponse, error) {
+	return c.updateSubscription(ctx, fmt.Sprintf(cancelSubscriptionURL, id))
+}
+
+// updateSubscription sends the subscription update, updates set the subscription's state so they are safe to retry
+func (c *Client) updateSubscription(ctx context.Context, path string) (*SubscriptionResponse, error) {
+	payload := struct {
+		SECKEY string `json:"seckey"`
+	}{c.SecretKey}
+
+	resp := &SubscriptionResponse{}
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = SubscriptionResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(path), payload, resp)
+	}, nil)
+	return resp, err
+}
@@ -0,0 +1,85 @@
+package ravepay
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ListSubscriptions(t *testing.T) {
+	handler := &transferTestServer{resp: subscriptionListResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.ListSubscriptions(1)
+	if err != nil {
+		t.Fatalf("Client.ListSubscriptions() error = %v", err)
+	}
+	if handler.path != querySubscriptionsURL || handler.query["page"] != "1" || handler.query["seckey"] != "sec-key" {
+		t.Errorf("request = %s %v", handler.path, handler.query)
+	}
+	if len(got.Data.Subscriptions) != 1 || got.Data.Subscriptions[0].PlanID != 1018 {
+		t.Errorf("Client.ListSubscriptions() = %+v", got.Data)
+	}
+}
+
+func TestClient_GetSubscription(t *testing.T) {
+	handler := &transferTestServer{resp: subscriptionListResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.GetSubscription(2341)
+	if err != nil {
+		t.Fatalf("Client.GetSubscription() error = %v", err)
+	}
+	if handler.query["id"] != "2341" {
+		t.Errorf("request query = %v", handler.query)
+	}
+	if got.Data.ID != 2341 || got.Data.Email != "user@example.com" {
+		t.Errorf("Client.GetSubscription() = %+v", got.Data)
+	}
+
+	handler.resp = emptySubscriptionListResponse
+	if _, err := c.GetSubscription(1); err != errSubscriptionNotFound {
+		t.Errorf("Client.GetSubscription() error = %v, want %v", err, errSubscriptionNotFound)
+	}
+}
+
+func TestClient_updateSubscription(t *testing.T) {
+	handler := &transferTestServer{resp: subscriptionResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+
+	tests := []struct {
+		name     string
+		call     func(int) (*SubscriptionResponse, error)
+		wantPath string
+	}{
+		{name: "activates the subscription", call: c.ActivateSubscription, wantPath: fmt.Sprintf(activateSubscriptionURL, 2341)},
+		{name: "cancels the subscription", call: c.CancelSubscription, wantPath: fmt.Sprintf(cancelSubscriptionURL, 2341)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call(2341)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if handler.path != tt.wantPath || handler.payload["seckey"] != "sec-key" {
+				t.Errorf("request = %s %v, want %s", handler.path, handler.payload, tt.wantPath)
+			}
+			if got.Data.ID != 2341 {
+				t.Errorf("got %+v", got.Data)
+			}
+		})
+	}
+}
+
+var subscriptionResponse = `{"status":"success","message":"SUBSCRIPTION-UPDATED","data":{"id":2341,"amount":1000,"customer":{"id":312123,"email":"user@example.com","AccountId":134},"customer_email":"user@example.com","plan":1018,"status":"active","date_created":"2020-01-01T08:34:24.000Z","date_cancelled":null}}`
+
+var subscriptionListResponse = `{"status":"success","message":"SUBSCRIPTIONS-FETCHED","data":{"page_info":{"total":1,"current_page":1,"total_pages":1},"plansubscriptions":[{"id":2341,"amount":1000,"customer":{"id":312123,"email":"user@example.com","AccountId":134},"customer_email":"user@example.com","plan":1018,"status":"active","date_created":"2020-01-01T08:34:24.000Z","date_cancelled":null}]}}`
+
+var emptySubscriptionListResponse = `{"status":"success","message":"SUBSCRIPTIONS-FETCHED","data":{"page_info":{"total":0,"current_page":0,"total_pages":0},"plansubscriptions":[]}}`
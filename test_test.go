@@ -4,8 +4,12 @@ import (
 	"log"
 	"net/http"
 	"testing"
+	"time"
 )
 
+// testExpiryYear is the expiry year of the test cards, a year from now so they never expire
+var testExpiryYear = time.Now().AddDate(1, 0, 0).Format("06")
+
 type testServer struct {
 	resp []byte
 }
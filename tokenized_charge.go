@@ -0,0 +1,190 @@
+package ravepay
+
+import (
+	"context"
+	"fmt"
+)
+
+// TokenizedChargeRequest holds the information for charging a card with its embed token
+// https://developer.flutterwave.com/v2.0/reference#tokenized-charge
+type TokenizedChargeRequest struct {
+	SECKEY            string            `json:"SECKEY,omitempty"`
+	Token             string            `json:"token"`
+	Amount            Money             `json:"amount"`
+	Currency          string            `json:"currency"`
+	Country           string            `json:"country,omitempty"`
+	Email             string            `json:"email"`
+	FirstName         string            `json:"firstname,omitempty"`
+	LastName          string            `json:"lastname,omitempty"`
+	IP                string            `json:"IP,omitempty"`
+	Narration         string            `json:"narration,omitempty"`
+	TxRef             string            `json:"txRef"`
+	DeviceFingerprint string            `json:"device_fingerprint,omitempty"`
+	Subaccounts       []SubaccountSplit `json:"subaccounts,omitempty"`
+}
+
+// BulkTokenizedChargeRequest holds the information for charging several embed tokens in one request
+type BulkTokenizedChargeRequest struct {
+	SECKEY        string                   `json:"seckey"`
+	Title         string                   `json:"title,omitempty"`
+	RetryStrategy *BulkChargeRetryStrategy `json:"retry_strategy,omitempty"`
+	// Charges are the tokenized charges, their SECKEY is ignored
+	Charges []TokenizedChargeRequest `json:"bulk_data"`
+}
+
+// BulkChargeRetryStrategy tells rave how to retry the failed charges of a bulk tokenized charge
+type BulkChargeRetryStrategy struct {
+	// RetryInterval is the number of minutes between retries
+	RetryInterval int `json:"retry_interval"`
+	// RetryAmountVariable is the percentage of the amount to charge on retries
+	RetryAmountVariable float64 `json:"retry_amount_variable"`
+	// RetryAttemptVariable is the number of retries
+	RetryAttemptVariable int `json:"retry_attempt_variable"`
+}
+
+// BulkTokenizedChargeResponse is a type of rave response for a bulk tokenized charge request
+type BulkTokenizedChargeResponse struct {
+	Data struct {
+		ID            int                     `json:"id"`
+		Title         string                  `json:"title"`
+		RetryStrategy BulkChargeRetryStrategy `json:"retry_strategy"`
+		Status        string                  `json:"status"`
+		DateCreated   string                  `json:"date_created"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// Validate checks the tokenized charge has a token, a txRef, a positive amount, a well formed email,
+// a currency and country rave accepts and valid subaccount splits
+func (t *TokenizedChargeRequest) Validate() error {
+	return t.validate("").err()
+}
+
+// validate returns the errors of Validate with their fields prefixed with field e.g bulk_data[0].
+func (t *TokenizedChargeRequest) validate(field string) ValidationErrors {
+	var errs ValidationErrors
+	if t.Token == "" {
+		errs = append(errs, &ValidationError{Field: "token", Message: "is required"})
+	}
+	if t.TxRef == "" {
+		errs = append(errs, &ValidationError{Field: "txRef", Message: "is required"})
+	}
+	if t.Amount.Minor <= 0 {
+		errs = append(errs, &ValidationError{Field: "amount", Message: "must be positive"})
+	}
+	if t.Email == "" {
+		errs = append(errs, &ValidationError{Field: "email", Message: "is required"})
+	} else if !validEmail(t.Email) {
+		errs = append(errs, &ValidationError{Field: "email", Message: "is malformed"})
+	}
+	errs = errs.add("currency", validateMarket("tokenized", t.Country, t.Currency, nil))
+	errs = errs.add("amount", validateAmountPrecision(t.Amount, t.Currency))
+	errs = errs.add("subaccounts", validateSplits(t.Amount, t.Subaccounts))
+	for _, err := range errs {
+		err.Field = field + err.Field
+	}
+	return errs
+}
+
+// TokenizedCharge charges the card the embed token was issued for
+// It sets the secret key on the request if empty
+func TokenizedCharge(t *TokenizedChargeRequest) (*ChargeResponse, error) {
+	return TokenizedChargeContext(context.Background(), t)
+}
+
+// TokenizedChargeContext is like TokenizedCharge but the request is bound to the given context
+func TokenizedChargeContext(ctx context.Context, t *TokenizedChargeRequest) (*ChargeResponse, error) {
+	return defaultClient().TokenizedChargeContext(ctx, t)
+}
+
+// TokenizedCharge charges the card the embed token was issued for
+// It sets the secret key on the request if empty
+func (c *Client) TokenizedCharge(t *TokenizedChargeRequest) (*ChargeResponse, error) {
+	return c.TokenizedChargeContext(context.Background(), t)
+}
+
+// TokenizedChargeContext is like TokenizedCharge but the request is bound to the given context
+func (c *Client) TokenizedChargeContext(ctx context.Context, t *TokenizedChargeRequest) (*ChargeResponse, error) {
+	if err := t.Validate(); err != nil {
+		return &ChargeResponse{}, err
+	}
+
+	if t.SECKEY == "" {
+		t.SECKEY = c.SecretKey
+	}
+	return c.sendCharge(ctx, c.buildURL(tokenizedChargeURL), t.TxRef, t)
+}
+
+// BulkTokenizedCharge makes a request to charge several embed tokens
+// rave processes the charges asynchronously, so the response only acknowledges the bulk charge
+// It sets the secret key on the request if empty
+func BulkTokenizedCharge(b *BulkTokenizedChargeRequest) (*BulkTokenizedChargeResponse, error) {
+	return BulkTokenizedChargeContext(context.Background(), b)
+}
+
+// BulkTokenizedChargeContext is like BulkTokenizedCharge but the request is bound to the given context
+func BulkTokenizedChargeContext(ctx context.Context, b *BulkTokenizedChargeRequest) (*BulkTokenizedChargeResponse, error) {
+	return defaultClient().BulkTokenizedChargeContext(ctx, b)
+}
+
+// BulkTokenizedCharge makes a request to charge several embed tokens
+// rave processes the charges asynchronously, so the response only acknowledges the bulk charge
+// It sets the secret key on the request if empty
+func (c *Client) BulkTokenizedCharge(b *BulkTokenizedChargeRequest) (*BulkTokenizedChargeResponse, error) {
+	return c.BulkTokenizedChargeContext(context.Background(), b)
+}
+
+// BulkTokenizedChargeContext is like BulkTokenizedCharge but the request is bound to the given context
+func (c *Client) BulkTokenizedChargeContext(ctx context.Context, b *BulkTokenizedChargeRequest) (*BulkTokenizedChargeResponse, error) {
+	resp := &BulkTokenizedChargeResponse{}
+	var errs ValidationErrors
+	for i := range b.Charges {
+		errs = append(errs, b.Charges[i].validate(fmt.Sprintf("bulk_data[%d].", i))...)
+	}
+	if err := errs.err(); err != nil {
+		return resp, err
+	}
+
+	if b.SECKEY == "" {
+		b.SECKEY = c.SecretKey
+	}
+
+	// the charges are copied so their SECKEY is left out without changing the request
+	payload := *b
+	payload.Charges = make([]TokenizedChargeRequest, len(b.Charges))
+	for i, charge := range b.Charges {
+		charge.SECKEY = ""
+		payload.Charges[i] = charge
+	}
+
+	// a bulk charge has no reference to look it up by, so it is never re-sent
+	err := c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(bulkTokenizedChargeURL), &payload, resp)
+	return resp, err
+}
+
+// EmbedToken returns the token for charging the card again with TokenizedCharge
+// It returns an empty string if the charge didn't issue a token
+func (cr *ChargeResponse) EmbedToken() string {
+	return cr.Data.ChargeToken.EmbedToken
+}
+
+// EmbedToken returns the token for charging the card again with TokenizedCharge
+// It returns an empty string if the validated charge didn't issue a token
+func (cv *ChargeValidationResponse) EmbedToken() string {
+	return cv.Data.Tx.ChargeToken.EmbedToken
+}
+
+// EmbedToken returns the token for charging the verified card again with TokenizedCharge
+// It returns an empty string if the transaction wasn't a card transaction or no token was issued
+func (t *TxnVerificationResponse) EmbedToken() string {
+	if t.Data.Card.LifeTimeToken != "" {
+		return t.Data.Card.LifeTimeToken
+	}
+	for _, token := range t.Data.Card.CardTokens {
+		if token.Embedtoken != "" {
+			return token.Embedtoken
+		}
+	}
+	return ""
+}
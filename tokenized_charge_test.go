@@ -0,0 +1,118 @@
+package ravepay
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestClient_TokenizedCharge(t *testing.T) {
+	handler := &transferTestServer{resp: successfulTokenizedChargeResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+
+	_, err := c.TokenizedCharge(&TokenizedChargeRequest{Amount: NewMoney(30000, ""), Currency: "NGN", Email: "user@example.com", TxRef: "MC-1"})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Client.TokenizedCharge() error = %v, want a validation error for the missing token", err)
+	}
+
+	got, err := c.TokenizedCharge(&TokenizedChargeRequest{
+		Token:    "flw-t0-349218908feb91c1dda7ca991a4a4b3a-m03k",
+		Amount:   NewMoney(30000, ""),
+		Currency: "NGN",
+		Email:    "user@example.com",
+		TxRef:    "MC-1",
+	})
+	if err != nil {
+		t.Fatalf("Client.TokenizedCharge() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"SECKEY":   "sec-key",
+		"token":    "flw-t0-349218908feb91c1dda7ca991a4a4b3a-m03k",
+		"amount":   float64(300),
+		"currency": "NGN",
+		"email":    "user@example.com",
+		"txRef":    "MC-1",
+	}
+	if handler.path != tokenizedChargeURL || !reflect.DeepEqual(handler.payload, want) {
+		t.Errorf("request = %s %v, want %s %v", handler.path, handler.payload, tokenizedChargeURL, want)
+	}
+	if got.Data.Status != "successful" || got.EmbedToken() != "flw-t0-349218908feb91c1dda7ca991a4a4b3a-m03k" {
+		t.Errorf("Client.TokenizedCharge() = %+v", got.Data)
+	}
+}
+
+func TestClient_BulkTokenizedCharge(t *testing.T) {
+	handler := &transferTestServer{resp: successfulBulkTokenizedChargeResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+
+	valid := TokenizedChargeRequest{Token: "flw-t0-1", Amount: NewMoney(30000, ""), Currency: "NGN", Email: "user@example.com", TxRef: "MC-1"}
+	invalid := TokenizedChargeRequest{Token: "flw-t0-2", Amount: NewMoney(30000, ""), Currency: "NGN", Email: "user@example.com", Subaccounts: []SubaccountSplit{{TransactionSplitRatio: 1}}}
+	_, err := c.BulkTokenizedCharge(&BulkTokenizedChargeRequest{Charges: []TokenizedChargeRequest{valid, invalid}})
+	if got, want := fieldsOf(err), []string{"bulk_data[1].txRef", "bulk_data[1].subaccounts[0].id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.BulkTokenizedCharge() error = %v, want validation errors for %v", err, want)
+	}
+	if handler.path != "" {
+		t.Errorf("Client.BulkTokenizedCharge() sent an invalid bulk charge to %s", handler.path)
+	}
+
+	withKey := valid
+	withKey.SECKEY = "sec-key"
+	charges := []TokenizedChargeRequest{withKey}
+	got, err := c.BulkTokenizedCharge(&BulkTokenizedChargeRequest{
+		Title:         "monthly renewals",
+		RetryStrategy: &BulkChargeRetryStrategy{RetryInterval: 120, RetryAmountVariable: 60, RetryAttemptVariable: 2},
+		Charges:       charges,
+	})
+	if err != nil {
+		t.Fatalf("Client.BulkTokenizedCharge() error = %v", err)
+	}
+	if got.Data.ID != 94 {
+		t.Errorf("Client.BulkTokenizedCharge() = %+v", got.Data)
+	}
+
+	wantCharges := []interface{}{
+		map[string]interface{}{"token": "flw-t0-1", "amount": float64(300), "currency": "NGN", "email": "user@example.com", "txRef": "MC-1"},
+	}
+	if handler.path != bulkTokenizedChargeURL || handler.payload["seckey"] != "sec-key" || !reflect.DeepEqual(handler.payload["bulk_data"], wantCharges) {
+		t.Errorf("request = %s %v, want bulk_data %v", handler.path, handler.payload, wantCharges)
+	}
+	if charges[0].SECKEY != "sec-key" {
+		t.Errorf("Client.BulkTokenizedCharge() changed the charges of the request: %+v", charges[0])
+	}
+}
+
+func TestEmbedToken(t *testing.T) {
+	verification := &TxnVerificationResponse{}
+	if err := json.Unmarshal([]byte(successfulCardVerifyPaymentResponse), verification); err != nil {
+		t.Fatal(err)
+	}
+	if token := verification.EmbedToken(); token != "" {
+		t.Errorf("TxnVerificationResponse.EmbedToken() = %s, want no token", token)
+	}
+
+	verification.Data.Card.LifeTimeToken = "flw-t1nf-5b0f12d565cd961f73c51370b1340f1f-m03k"
+	if token := verification.EmbedToken(); token != "flw-t1nf-5b0f12d565cd961f73c51370b1340f1f-m03k" {
+		t.Errorf("TxnVerificationResponse.EmbedToken() = %s, want the life time token", token)
+	}
+
+	validation := &ChargeValidationResponse{}
+	if err := json.Unmarshal([]byte(successfulValidateChargeCardResponse), validation); err != nil {
+		t.Fatal(err)
+	}
+	if token := validation.EmbedToken(); token != "flw-t0-349218908feb91c1dda7ca991a4a4b3a-m03k" {
+		t.Errorf("ChargeValidationResponse.EmbedToken() = %s, want the charge token", token)
+	}
+}
+
+var successfulTokenizedChargeResponse = `{"status":"success","message":"Charge success","data":{"id":125837,"txRef":"MC-1","orderRef":"URF_1522240214196_5404135","flwRef":"FLW-M03K-1e86c5c4bc7a6a3cc09c0dfcc0bc5d3f","redirectUrl":"http://127.0.0","device_fingerprint":"N/A","settlement_token":null,"cycle":"one-time","amount":300,"charged_amount":300,"appfee":0,"merchantfee":0,"merchantbearsfee":1,"chargeResponseCode":"00","raveRef":null,"chargeResponseMessage":"Approved","authModelUsed":"noauth","currency":"NGN","IP":"::ffff:10.111.40.10","narration":"Synergy Group","status":"successful","vbvrespmessage":"Approved","authurl":"N/A","vbvrespcode":"00","acctvalrespmsg":null,"acctvalrespcode":null,"paymentType":"card","paymentPlan":null,"paymentPage":null,"paymentId":"861","fraud_status":"ok","charge_type":"normal","is_live":0,"createdAt":"2018-03-28T12:30:14.000Z","updatedAt":"2018-03-28T12:30:15.000Z","deletedAt":null,"customerId":20331,"AccountId":134,"customer":{"id":20331,"phone":null,"fullName":"Anonymous customer","customertoken":null,"email":"user@example.com","createdAt":"2018-03-18T02:54:57.000Z","updatedAt":"2018-03-18T02:54:57.000Z","deletedAt":null,"AccountId":134},"chargeToken":{"user_token":"9b9c3","embed_token":"flw-t0-349218908feb91c1dda7ca991a4a4b3a-m03k"}}}`
+
+var successfulBulkTokenizedChargeResponse = `{"status":"success","message":"BULK-CHARGE-INITIATED","data":{"id":94,"title":"monthly renewals","retry_strategy":{"retry_interval":120,"retry_amount_variable":60,"retry_attempt_variable":2},"status":"queued","date_created":"2018-06-20T09:41:47.000Z"}}`
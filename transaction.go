@@ -1,6 +1,9 @@
 package ravepay
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // TxnVerificationResponse is a type of rave response for transaction verification request
 // it implements the verifiable interface to allow rave's recommended followup verification
@@ -12,24 +15,25 @@ type TxnVerificationResponse struct {
 
 // XRQTxnVerificationResponse is a type of rave response for xrquery transaction verification request
 // it implements the verifiable interface to allow rave's recommended followup verification
+// If rave responds with every attempt of the transaction, Data is the latest attempt
 type XRQTxnVerificationResponse struct {
-	Data    xRQTxnVerificationResponseData `json:"data"`
-	Message string                         `json:"message"`
-	Status  string                         `json:"status"`
+	Data    XRQTxnAttempt `json:"data"`
+	Message string        `json:"message"`
+	Status  string        `json:"status"`
 }
 
 type txnVerificationResponseData struct {
 	Account Account `json:"account"`
 
-	AddonID int `json:"addon_id"`
-	Amount  int `json:"amount"`
-	Appfee  int `json:"appfee"`
+	AddonID int   `json:"addon_id"`
+	Amount  Money `json:"amount"`
+	Appfee  Money `json:"appfee"`
 
 	Card Card `json:"card"`
 
 	ChargeType        string      `json:"charge_type"`
 	ChargebackStatus  interface{} `json:"chargeback_status"`
-	ChargedAmount     int         `json:"charged_amount"`
+	ChargedAmount     Money       `json:"charged_amount"`
 	CreatedAt         string      `json:"createdAt"`
 	Code              string      `json:"code"`
 	Customer          Customer    `json:"customer"`
@@ -46,7 +50,7 @@ type txnVerificationResponseData struct {
 	Message           string      `json:"message"`
 	MerchantID        int         `json:"merchant_id"`
 	Merchantbearsfee  int         `json:"merchantbearsfee"`
-	Merchantfee       int         `json:"merchantfee"`
+	Merchantfee       Money       `json:"merchantfee"`
 	Meta              []struct {
 		CreatedAt            string      `json:"createdAt"`
 		DeletedAt            interface{} `json:"deletedAt"`
@@ -71,67 +75,68 @@ type txnVerificationResponseData struct {
 	UpdatedAt            string      `json:"updatedAt"`
 }
 
-type xRQTxnVerificationResponseData struct {
-	Accountid                       int         `json:"accountid"`
-	Acctalias                       string      `json:"acctalias"`
-	Acctbearsfeeattransactiontime   int         `json:"acctbearsfeeattransactiontime"`
-	Acctbusinessname                string      `json:"acctbusinessname"`
-	Acctcode                        interface{} `json:"acctcode"`
-	Acctcontactperson               string      `json:"acctcontactperson"`
-	Acctcountry                     string      `json:"acctcountry"`
-	Acctisliveapproved              int         `json:"acctisliveapproved"`
-	Acctmessage                     interface{} `json:"acctmessage"`
-	Acctparent                      int         `json:"acctparent"`
-	Acctvpcmerchant                 string      `json:"acctvpcmerchant"`
-	Amount                          int         `json:"amount"`
-	Amountsettledforthistransaction int         `json:"amountsettledforthistransaction"`
-	Appfee                          int         `json:"appfee"`
-	Authmodel                       string      `json:"authmodel"`
-	Authurl                         string      `json:"authurl"`
-	Chargecode                      string      `json:"chargecode"`
-	Chargedamount                   int         `json:"chargedamount"`
-	Chargemessage                   string      `json:"chargemessage"`
-	Chargetype                      string      `json:"chargetype"`
-	Created                         string      `json:"created"`
-	Createdday                      int         `json:"createdday"`
-	Createddayispublicholiday       int         `json:"createddayispublicholiday"`
-	Createddayname                  string      `json:"createddayname"`
-	Createdhour                     int         `json:"createdhour"`
-	Createdminute                   int         `json:"createdminute"`
-	Createdmonth                    int         `json:"createdmonth"`
-	Createdmonthname                string      `json:"createdmonthname"`
-	Createdpmam                     string      `json:"createdpmam"`
-	Createdquarter                  int         `json:"createdquarter"`
-	Createdweek                     int         `json:"createdweek"`
-	Createdyear                     int         `json:"createdyear"`
-	Createdyearisleap               bool        `json:"createdyearisleap"`
-	Currency                        string      `json:"currency"`
-	Custcreated                     string      `json:"custcreated"`
-	Custemail                       string      `json:"custemail"`
-	Custemailprovider               string      `json:"custemailprovider"`
-	Custname                        string      `json:"custname"`
-	Custnetworkprovider             string      `json:"custnetworkprovider"`
-	Customerid                      int         `json:"customerid"`
-	Custphone                       interface{} `json:"custphone"`
-	Cycle                           string      `json:"cycle"`
-	Devicefingerprint               string      `json:"devicefingerprint"`
-	Flwref                          string      `json:"flwref"`
-	Fraudstatus                     string      `json:"fraudstatus"`
-	IP                              string      `json:"ip"`
-	Merchantbearsfee                int         `json:"merchantbearsfee"`
-	Merchantfee                     int         `json:"merchantfee"`
-	Narration                       string      `json:"narration"`
-	Orderref                        string      `json:"orderref"`
-	Paymentid                       string      `json:"paymentid"`
-	Paymentpage                     interface{} `json:"paymentpage"`
-	Paymentplan                     interface{} `json:"paymentplan"`
-	Paymenttype                     string      `json:"paymenttype"`
-	Raveref                         interface{} `json:"raveref"`
-	Status                          string      `json:"status"`
-	Txid                            int         `json:"txid"`
-	Txref                           string      `json:"txref"`
-	Vbvcode                         string      `json:"vbvcode"`
-	Vbvmessage                      string      `json:"vbvmessage"`
+// XRQTxnAttempt is an attempt to pay for a transaction as returned by xrequery
+type XRQTxnAttempt struct {
+	Accountid                       int          `json:"accountid"`
+	Acctalias                       string       `json:"acctalias"`
+	Acctbearsfeeattransactiontime   int          `json:"acctbearsfeeattransactiontime"`
+	Acctbusinessname                string       `json:"acctbusinessname"`
+	Acctcode                        interface{}  `json:"acctcode"`
+	Acctcontactperson               string       `json:"acctcontactperson"`
+	Acctcountry                     string       `json:"acctcountry"`
+	Acctisliveapproved              int          `json:"acctisliveapproved"`
+	Acctmessage                     interface{}  `json:"acctmessage"`
+	Acctparent                      int          `json:"acctparent"`
+	Acctvpcmerchant                 string       `json:"acctvpcmerchant"`
+	Amount                          Money        `json:"amount"`
+	Amountsettledforthistransaction Money        `json:"amountsettledforthistransaction"`
+	Appfee                          Money        `json:"appfee"`
+	Authmodel                       string       `json:"authmodel"`
+	Authurl                         string       `json:"authurl"`
+	Chargecode                      string       `json:"chargecode"`
+	Chargedamount                   Money        `json:"chargedamount"`
+	Chargemessage                   string       `json:"chargemessage"`
+	Chargetype                      string       `json:"chargetype"`
+	Created                         string       `json:"created"`
+	Createdday                      int          `json:"createdday"`
+	Createddayispublicholiday       int          `json:"createddayispublicholiday"`
+	Createddayname                  string       `json:"createddayname"`
+	Createdhour                     int          `json:"createdhour"`
+	Createdminute                   int          `json:"createdminute"`
+	Createdmonth                    int          `json:"createdmonth"`
+	Createdmonthname                string       `json:"createdmonthname"`
+	Createdpmam                     string       `json:"createdpmam"`
+	Createdquarter                  int          `json:"createdquarter"`
+	Createdweek                     int          `json:"createdweek"`
+	Createdyear                     int          `json:"createdyear"`
+	Createdyearisleap               bool         `json:"createdyearisleap"`
+	Currency                        string       `json:"currency"`
+	Custcreated                     string       `json:"custcreated"`
+	Custemail                       string       `json:"custemail"`
+	Custemailprovider               string       `json:"custemailprovider"`
+	Custname                        string       `json:"custname"`
+	Custnetworkprovider             string       `json:"custnetworkprovider"`
+	Customerid                      int          `json:"customerid"`
+	Custphone                       interface{}  `json:"custphone"`
+	Cycle                           string       `json:"cycle"`
+	Devicefingerprint               string       `json:"devicefingerprint"`
+	Flwref                          string       `json:"flwref"`
+	Fraudstatus                     string       `json:"fraudstatus"`
+	IP                              string       `json:"ip"`
+	Merchantbearsfee                int          `json:"merchantbearsfee"`
+	Merchantfee                     Money        `json:"merchantfee"`
+	Narration                       string       `json:"narration"`
+	Orderref                        string       `json:"orderref"`
+	Paymentid                       string       `json:"paymentid"`
+	Paymentpage                     interface{}  `json:"paymentpage"`
+	Paymentplan                     *PaymentPlan `json:"paymentplan"`
+	Paymenttype                     string       `json:"paymenttype"`
+	Raveref                         interface{}  `json:"raveref"`
+	Status                          string       `json:"status"`
+	Txid                            int          `json:"txid"`
+	Txref                           string       `json:"txref"`
+	Vbvcode                         string       `json:"vbvcode"`
+	Vbvmessage                      string       `json:"vbvmessage"`
 }
 
 // VerifyStatus verifies that response status is success
@@ -170,24 +175,46 @@ func (resp *XRQTxnVerificationResponse) VerifyCurrency(currency string) error {
 	return nil
 }
 
-// VerifyAmount verifies that the given amount matches that in the transaction
+// VerifyAmount verifies that the transaction's amount is at least the given amount
+// and that it is in the given amount's currency if it has one
 // returns error otherwise
-func (resp *TxnVerificationResponse) VerifyAmount(amt int) error {
-	if got := resp.Data.Amount; amt > got {
-		return fmt.Errorf("AmountVerificationFailed: expected %d but got %d", amt, got)
-	}
-	return nil
+func (resp *TxnVerificationResponse) VerifyAmount(amt Money) error {
+	return verifyAmount(amt, resp.Data.Amount, resp.Data.TransactionCurrency)
 }
 
-// VerifyAmount verifies that the given amount matches that in the transaction
+// VerifyAmount verifies that the transaction's amount is at least the given amount
+// and that it is in the given amount's currency if it has one
 // returns error otherwise
-func (resp *XRQTxnVerificationResponse) VerifyAmount(amt int) error {
-	if got := resp.Data.Amount; amt > got {
-		return fmt.Errorf("AmountVerificationFailed: expected %d but got %d", amt, got)
+func (resp *XRQTxnVerificationResponse) VerifyAmount(amt Money) error {
+	return verifyAmount(amt, resp.Data.Amount, resp.Data.Currency)
+}
+
+// verifyAmount checks the amount of a transaction in the currency is at least the expected amount
+// and that the transaction is in the expected amount's currency if it has one
+func verifyAmount(want, got Money, currency string) error {
+	c, err := compareAmount(want, got, currency)
+	if err != nil {
+		return err
+	}
+	if c > 0 {
+		return fmt.Errorf("AmountVerificationFailed: expected %s but got %s", want, got)
 	}
 	return nil
 }
 
+// compareAmount compares the expected amount with the amount of a transaction in the currency
+// the transaction's amount is decoded without its currency, so the currency is checked separately
+func compareAmount(want, got Money, currency string) (int, error) {
+	if want.Currency != "" && !strings.EqualFold(want.Currency, currency) {
+		return 0, fmt.Errorf("AmountVerificationFailed: expected %s %s but got %s %s", want, want.Currency, got, currency)
+	}
+	c, err := want.compare(got)
+	if err != nil {
+		return 0, fmt.Errorf("AmountVerificationFailed: %w", err)
+	}
+	return c, nil
+}
+
 // VerifyChargeResponseValue verifies that the charge response value for the transaction is either '0' or '00'
 func (resp *TxnVerificationResponse) VerifyChargeResponseValue() error {
 	if respVal := resp.Data.FlwMeta.ChargeResponse; respVal != "00" && respVal != "0" {
@@ -222,3 +249,35 @@ func (resp *XRQTxnVerificationResponse) VerifyReference(ref string) error {
 	}
 	return nil
 }
+
+// VerificationDetails returns the details of the transaction checked against a VerificationPolicy
+func (resp *TxnVerificationResponse) VerificationDetails() VerificationDetails {
+	d := resp.Data
+	return VerificationDetails{
+		Status:        resp.Status,
+		FlwRef:        d.FlwRef,
+		TxRef:         d.TxRef,
+		Currency:      d.TransactionCurrency,
+		Amount:        d.Amount,
+		ChargedAmount: d.ChargedAmount,
+		ChargeCode:    d.FlwMeta.ChargeResponse,
+		FraudStatus:   d.FraudStatus,
+		PaymentEntity: d.PaymentEntity,
+	}
+}
+
+// VerificationDetails returns the details of the transaction checked against a VerificationPolicy
+func (resp *XRQTxnVerificationResponse) VerificationDetails() VerificationDetails {
+	d := resp.Data
+	return VerificationDetails{
+		Status:        resp.Status,
+		FlwRef:        d.Flwref,
+		TxRef:         d.Txref,
+		Currency:      d.Currency,
+		Amount:        d.Amount,
+		ChargedAmount: d.Chargedamount,
+		ChargeCode:    d.Chargecode,
+		FraudStatus:   d.Fraudstatus,
+		PaymentEntity: d.Paymenttype,
+	}
+}
@@ -97,7 +97,7 @@ func TestTxnVerificationResponse_VerifyAmount(t *testing.T) {
 		Status  string
 	}
 	type args struct {
-		amt int
+		amt Money
 	}
 	tests := []struct {
 		name    string
@@ -108,25 +108,25 @@ func TestTxnVerificationResponse_VerifyAmount(t *testing.T) {
 		{
 			name: "returns an error if amount is lesser than the given amount",
 			fields: fields{
-				Data: txnVerificationResponseData{Amount: 999},
+				Data: txnVerificationResponseData{Amount: NewMoney(99900, "")},
 			},
-			args:    args{amt: 1000},
+			args:    args{amt: NewMoney(100000, "")},
 			wantErr: true,
 		},
 		{
 			name: "doesn't return an error if amount equals the given amount",
 			fields: fields{
-				Data: txnVerificationResponseData{Amount: 1000},
+				Data: txnVerificationResponseData{Amount: NewMoney(100000, "")},
 			},
-			args:    args{amt: 1000},
+			args:    args{amt: NewMoney(100000, "")},
 			wantErr: false,
 		},
 		{
 			name: "doesn't return an error if amount is greater than the given amount",
 			fields: fields{
-				Data: txnVerificationResponseData{Amount: 2000},
+				Data: txnVerificationResponseData{Amount: NewMoney(200000, "")},
 			},
-			args:    args{amt: 1000},
+			args:    args{amt: NewMoney(100000, "")},
 			wantErr: false,
 		},
 	}
@@ -245,7 +245,7 @@ func TestTxnVerificationResponse_VerifyReference(t *testing.T) {
 
 func TestXRQTxnVerificationResponse_VerifyStatus(t *testing.T) {
 	type fields struct {
-		Data    xRQTxnVerificationResponseData
+		Data    XRQTxnAttempt
 		Message string
 		Status  string
 	}
@@ -285,7 +285,7 @@ func TestXRQTxnVerificationResponse_VerifyStatus(t *testing.T) {
 
 func TestXRQTxnVerificationResponse_VerifyCurrency(t *testing.T) {
 	type fields struct {
-		Data    xRQTxnVerificationResponseData
+		Data    XRQTxnAttempt
 		Message string
 		Status  string
 	}
@@ -301,7 +301,7 @@ func TestXRQTxnVerificationResponse_VerifyCurrency(t *testing.T) {
 		{
 			name: "returns an error if currency doesn't match the given currency",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Currency: "NGN"},
+				Data: XRQTxnAttempt{Currency: "NGN"},
 			},
 			args:    args{currency: "USD"},
 			wantErr: true,
@@ -309,7 +309,7 @@ func TestXRQTxnVerificationResponse_VerifyCurrency(t *testing.T) {
 		{
 			name: "doesn't return an error if currency matches the given currency",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Currency: "NGN"},
+				Data: XRQTxnAttempt{Currency: "NGN"},
 			},
 			args:    args{currency: "NGN"},
 			wantErr: false,
@@ -331,12 +331,12 @@ func TestXRQTxnVerificationResponse_VerifyCurrency(t *testing.T) {
 
 func TestXRQTxnVerificationResponse_VerifyAmount(t *testing.T) {
 	type fields struct {
-		Data    xRQTxnVerificationResponseData
+		Data    XRQTxnAttempt
 		Message string
 		Status  string
 	}
 	type args struct {
-		amt int
+		amt Money
 	}
 	tests := []struct {
 		name    string
@@ -347,25 +347,25 @@ func TestXRQTxnVerificationResponse_VerifyAmount(t *testing.T) {
 		{
 			name: "returns an error if amount is lesser than the given amount",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Amount: 999},
+				Data: XRQTxnAttempt{Amount: NewMoney(99900, "")},
 			},
-			args:    args{amt: 1000},
+			args:    args{amt: NewMoney(100000, "")},
 			wantErr: true,
 		},
 		{
 			name: "doesn't return an error if amount equals the given amount",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Amount: 1000},
+				Data: XRQTxnAttempt{Amount: NewMoney(100000, "")},
 			},
-			args:    args{amt: 1000},
+			args:    args{amt: NewMoney(100000, "")},
 			wantErr: false,
 		},
 		{
 			name: "doesn't return an error if amount is greater than the given amount",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Amount: 2000},
+				Data: XRQTxnAttempt{Amount: NewMoney(200000, "")},
 			},
-			args:    args{amt: 1000},
+			args:    args{amt: NewMoney(100000, "")},
 			wantErr: false,
 		},
 	}
@@ -385,7 +385,7 @@ func TestXRQTxnVerificationResponse_VerifyAmount(t *testing.T) {
 
 func TestXRQTxnVerificationResponse_VerifyChargeResponseValue(t *testing.T) {
 	type fields struct {
-		Data    xRQTxnVerificationResponseData
+		Data    XRQTxnAttempt
 		Message string
 		Status  string
 	}
@@ -397,21 +397,21 @@ func TestXRQTxnVerificationResponse_VerifyChargeResponseValue(t *testing.T) {
 		{
 			name: "returns an error if charge response is not 00 or 0",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Chargecode: "404"},
+				Data: XRQTxnAttempt{Chargecode: "404"},
 			},
 			wantErr: true,
 		},
 		{
 			name: "doesn't return an error if charge response is 0",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Chargecode: "00"},
+				Data: XRQTxnAttempt{Chargecode: "00"},
 			},
 			wantErr: false,
 		},
 		{
 			name: "doesn't return an error if charge response is 00",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Chargecode: "00"},
+				Data: XRQTxnAttempt{Chargecode: "00"},
 			},
 			wantErr: false,
 		},
@@ -432,7 +432,7 @@ func TestXRQTxnVerificationResponse_VerifyChargeResponseValue(t *testing.T) {
 
 func TestXRQTxnVerificationResponse_VerifyReference(t *testing.T) {
 	type fields struct {
-		Data    xRQTxnVerificationResponseData
+		Data    XRQTxnAttempt
 		Message string
 		Status  string
 	}
@@ -448,7 +448,7 @@ func TestXRQTxnVerificationResponse_VerifyReference(t *testing.T) {
 		{
 			name: "returns an error if flwref doesn't match the given ref",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Flwref: "another-ref"},
+				Data: XRQTxnAttempt{Flwref: "another-ref"},
 			},
 			args:    args{ref: "my-ref"},
 			wantErr: true,
@@ -456,7 +456,7 @@ func TestXRQTxnVerificationResponse_VerifyReference(t *testing.T) {
 		{
 			name: "doesn't return an error if flwref matches the given ref",
 			fields: fields{
-				Data: xRQTxnVerificationResponseData{Flwref: "my-ref"},
+				Data: XRQTxnAttempt{Flwref: "my-ref"},
 			},
 			args:    args{ref: "my-ref"},
 			wantErr: false,
@@ -0,0 +1,405 @@
+package ravepay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Transfer is a type of rave transfer (payout) resource
+type Transfer struct {
+	AccountNumber    string      `json:"account_number"`
+	Amount           Money       `json:"amount"`
+	BankCode         string      `json:"bank_code"`
+	BankName         string      `json:"bank_name"`
+	CompleteMessage  string      `json:"complete_message"`
+	Currency         string      `json:"currency"`
+	DateCreated      string      `json:"date_created"`
+	DebitCurrency    string      `json:"debit_currency"`
+	Fee              Money       `json:"fee"`
+	FullName         string      `json:"fullname"`
+	ID               int         `json:"id"`
+	IsApproved       int         `json:"is_approved"`
+	Meta             interface{} `json:"meta"`
+	Narration        string      `json:"narration"`
+	Reference        string      `json:"reference"`
+	RequiresApproval int         `json:"requires_approval"`
+	Status           string      `json:"status"`
+}
+
+// TransferBeneficiary is the destination of a transfer, a bank account or mobile money wallet
+type TransferBeneficiary struct {
+	// Bank is the destination bank, only its code is used
+	// for mobile money wallets it's the network code e.g MPS for mpesa
+	Bank          Bank
+	AccountNumber string
+	Name          string
+}
+
+// MobileMoneyBeneficiary returns a transfer beneficiary for the mobile money wallet
+// on the given network (e.g MPS, MTN, TIGO, VODAFONE) with the given phone number
+func MobileMoneyBeneficiary(network, phoneNumber, name string) TransferBeneficiary {
+	return TransferBeneficiary{
+		Bank:          Bank{Code: network},
+		AccountNumber: phoneNumber,
+		Name:          name,
+	}
+}
+
+// TransferRequest holds the information necessary for initiating a transfer
+// https://developer.flutterwave.com/v2.0/reference#initiate-transfer
+type TransferRequest struct {
+	Beneficiary TransferBeneficiary
+	Amount      Money
+	Currency    string
+	Narration   string
+	// Reference is the merchant's unique reference for the transfer
+	// transfers without a reference are never re-sent by the retry policy
+	Reference   string
+	CallbackURL string
+}
+
+type transferPayload struct {
+	AccountBank     string `json:"account_bank"`
+	AccountNumber   string `json:"account_number"`
+	Amount          Money  `json:"amount"`
+	BeneficiaryName string `json:"beneficiary_name,omitempty"`
+	CallbackURL     string `json:"callback_url,omitempty"`
+	Currency        string `json:"currency"`
+	Narration       string `json:"narration,omitempty"`
+	Reference       string `json:"reference,omitempty"`
+	SECKEY          string `json:"seckey"`
+}
+
+// TransferResponse is a type of rave response for a single transfer
+// it implements the verifiable interface to allow verifying a transfer like a transaction
+type TransferResponse struct {
+	Data    Transfer `json:"data"`
+	Message string   `json:"message"`
+	Status  string   `json:"status"`
+}
+
+// BulkTransferRequest holds the information necessary for initiating a bulk transfer
+type BulkTransferRequest struct {
+	Title     string
+	Transfers []TransferRequest
+}
+
+type bulkTransferItem struct {
+	Bank          string `json:"Bank"`
+	AccountNumber string `json:"Account Number"`
+	Amount        Money  `json:"Amount"`
+	Currency      string `json:"Currency"`
+	Narration     string `json:"Narration"`
+	Reference     string `json:"reference,omitempty"`
+}
+
+// BulkTransferResponse is a type of rave response for a bulk transfer request
+type BulkTransferResponse struct {
+	Data struct {
+		Approver    string `json:"approver"`
+		DateCreated string `json:"date_created"`
+		ID          int    `json:"id"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// TransferListResponse is a type of rave response for listing transfers
+type TransferListResponse struct {
+	Data struct {
+		PageInfo  PageInfo   `json:"page_info"`
+		Transfers []Transfer `json:"transfers"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// PageInfo describes a page of a paginated rave list response
+type PageInfo struct {
+	CurrentPage int `json:"current_page"`
+	Total       int `json:"total"`
+	TotalPages  int `json:"total_pages"`
+}
+
+// HasNextPage reports whether there are pages after the current page
+func (p PageInfo) HasNextPage() bool {
+	return p.CurrentPage < p.TotalPages
+}
+
+// TransferFeeResponse is a type of rave response for the transfer fee request
+type TransferFeeResponse struct {
+	Data []struct {
+		Currency string `json:"currency"`
+		Entity   string `json:"entity"`
+		Fee      Money  `json:"fee"`
+		FeeType  string `json:"fee_type"`
+		ID       int    `json:"id"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// InitiateTransfer makes a request to transfer the amount to the beneficiary in the transfer request
+// https://developer.flutterwave.com/v2.0/reference#initiate-transfer
+func InitiateTransfer(tr *TransferRequest) (*TransferResponse, error) {
+	return InitiateTransferContext(context.Background(), tr)
+}
+
+// InitiateTransferContext is like InitiateTransfer but the request is bound to the given context
+func InitiateTransferContext(ctx context.Context, tr *TransferRequest) (*TransferResponse, error) {
+	return defaultClient().InitiateTransferContext(ctx, tr)
+}
+
+// InitiateTransfer makes a request to transfer the amount to the beneficiary in the transfer request
+// https://developer.flutterwave.com/v2.0/reference#initiate-transfer
+func (c *Client) InitiateTransfer(tr *TransferRequest) (*TransferResponse, error) {
+	return c.InitiateTransferContext(context.Background(), tr)
+}
+
+// InitiateTransferContext is like InitiateTransfer but the request is bound to the given context
+func (c *Client) InitiateTransferContext(ctx context.Context, tr *TransferRequest) (*TransferResponse, error) {
+	payload := transferPayload{
+		AccountBank:     tr.Beneficiary.Bank.Code,
+		AccountNumber:   tr.Beneficiary.AccountNumber,
+		Amount:          tr.Amount,
+		BeneficiaryName: tr.Beneficiary.Name,
+		CallbackURL:     tr.CallbackURL,
+		Currency:        tr.Currency,
+		Narration:       tr.Narration,
+		Reference:       tr.Reference,
+		SECKEY:          c.SecretKey,
+	}
+
+	resp := &TransferResponse{}
+	recovered := false
+	// the transfer is only re-sent if rave has no record of a transfer with the reference
+	resend := func(ctx context.Context) bool {
+		if tr.Reference == "" {
+			return false
+		}
+		lookup, err := c.getTransfer(ctx, tr.Reference)
+		if err == nil {
+			*resp = *lookup
+			recovered = true
+			return false
+		}
+		return errors.Is(err, ErrTransferNotFound)
+	}
+
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = TransferResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(createTransferURL), payload, resp)
+	}, resend)
+	if recovered {
+		err = nil
+	}
+
+	return resp, err
+}
+
+// InitiateBulkTransfer makes a request to make all the transfers in the bulk transfer request
+// Bulk transfers are never re-sent by the retry policy
+// https://developer.flutterwave.com/v2.0/reference#initiate-bulk-transfer
+func InitiateBulkTransfer(btr *BulkTransferRequest) (*BulkTransferResponse, error) {
+	return InitiateBulkTransferContext(context.Background(), btr)
+}
+
+// InitiateBulkTransferContext is like InitiateBulkTransfer but the request is bound to the given context
+func InitiateBulkTransferContext(ctx context.Context, btr *BulkTransferRequest) (*BulkTransferResponse, error) {
+	return defaultClient().InitiateBulkTransferContext(ctx, btr)
+}
+
+// InitiateBulkTransfer makes a request to make all the transfers in the bulk transfer request
+// Bulk transfers are never re-sent by the retry policy
+// https://developer.flutterwave.com/v2.0/reference#initiate-bulk-transfer
+func (c *Client) InitiateBulkTransfer(btr *BulkTransferRequest) (*BulkTransferResponse, error) {
+	return c.InitiateBulkTransferContext(context.Background(), btr)
+}
+
+// InitiateBulkTransferContext is like InitiateBulkTransfer but the request is bound to the given context
+func (c *Client) InitiateBulkTransferContext(ctx context.Context, btr *BulkTransferRequest) (*BulkTransferResponse, error) {
+	items := make([]bulkTransferItem, len(btr.Transfers))
+	for i, tr := range btr.Transfers {
+		items[i] = bulkTransferItem{
+			Bank:          tr.Beneficiary.Bank.Code,
+			AccountNumber: tr.Beneficiary.AccountNumber,
+			Amount:        tr.Amount,
+			Currency:      tr.Currency,
+			Narration:     tr.Narration,
+			Reference:     tr.Reference,
+		}
+	}
+
+	payload := struct {
+		SECKEY   string             `json:"seckey"`
+		Title    string             `json:"title,omitempty"`
+		BulkData []bulkTransferItem `json:"bulk_data"`
+	}{c.SecretKey, btr.Title, items}
+
+	resp := &BulkTransferResponse{}
+	err := c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(createBulkTransferURL), payload, resp)
+	return resp, err
+}
+
+// GetTransfer returns the transfer with the given reference
+func GetTransfer(reference string) (*TransferResponse, error) {
+	return GetTransferContext(context.Background(), reference)
+}
+
+// GetTransferContext is like GetTransfer but the request is bound to the given context
+func GetTransferContext(ctx context.Context, reference string) (*TransferResponse, error) {
+	return defaultClient().GetTransferContext(ctx, reference)
+}
+
+// GetTransfer returns the transfer with the given reference
+func (c *Client) GetTransfer(reference string) (*TransferResponse, error) {
+	return c.GetTransferContext(context.Background(), reference)
+}
+
+// GetTransferContext is like GetTransfer but the request is bound to the given context
+func (c *Client) GetTransferContext(ctx context.Context, reference string) (*TransferResponse, error) {
+	var resp *TransferResponse
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = c.getTransfer(ctx, reference)
+		return err
+	}, nil)
+	return resp, err
+}
+
+// ErrTransferNotFound is returned by GetTransfer when no transfer matches the reference
+var ErrTransferNotFound = errors.New("ravepay: no transfer matches the reference")
+
+func (c *Client) getTransfer(ctx context.Context, reference string) (*TransferResponse, error) {
+	list, err := c.listTransfers(ctx, url.Values{"reference": {reference}})
+	resp := &TransferResponse{Status: list.Status, Message: list.Message}
+	if err != nil {
+		return resp, err
+	}
+
+	if len(list.Data.Transfers) == 0 {
+		return resp, ErrTransferNotFound
+	}
+	resp.Data = list.Data.Transfers[0]
+	return resp, nil
+}
+
+// ListTransfers returns the given page of the merchant's transfers, pages start from 1
+// status optionally filters the transfers by status e.g successful, failed
+func ListTransfers(page int, status string) (*TransferListResponse, error) {
+	return ListTransfersContext(context.Background(), page, status)
+}
+
+// ListTransfersContext is like ListTransfers but the request is bound to the given context
+func ListTransfersContext(ctx context.Context, page int, status string) (*TransferListResponse, error) {
+	return defaultClient().ListTransfersContext(ctx, page, status)
+}
+
+// ListTransfers returns the given page of the merchant's transfers, pages start from 1
+// status optionally filters the transfers by status e.g successful, failed
+func (c *Client) ListTransfers(page int, status string) (*TransferListResponse, error) {
+	return c.ListTransfersContext(context.Background(), page, status)
+}
+
+// ListTransfersContext is like ListTransfers but the request is bound to the given context
+func (c *Client) ListTransfersContext(ctx context.Context, page int, status string) (*TransferListResponse, error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if status != "" {
+		query.Set("status", status)
+	}
+
+	var resp *TransferListResponse
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = c.listTransfers(ctx, query)
+		return err
+	}, nil)
+	return resp, err
+}
+
+func (c *Client) listTransfers(ctx context.Context, query url.Values) (*TransferListResponse, error) {
+	q := url.Values{"seckey": {c.SecretKey}}
+	for k, v := range query {
+		q[k] = v
+	}
+
+	resp := &TransferListResponse{}
+	err := c.sendRequestAndParseResponse(ctx, "GET", c.buildURL(transfersURL)+"?"+q.Encode(), nil, resp)
+	return resp, err
+}
+
+// GetTransferFee returns rave's fee for transfers in the given currency
+func GetTransferFee(currency string) (*TransferFeeResponse, error) {
+	return GetTransferFeeContext(context.Background(), currency)
+}
+
+// GetTransferFeeContext is like GetTransferFee but the request is bound to the given context
+func GetTransferFeeContext(ctx context.Context, currency string) (*TransferFeeResponse, error) {
+	return defaultClient().GetTransferFeeContext(ctx, currency)
+}
+
+// GetTransferFee returns rave's fee for transfers in the given currency
+func (c *Client) GetTransferFee(currency string) (*TransferFeeResponse, error) {
+	return c.GetTransferFeeContext(context.Background(), currency)
+}
+
+// GetTransferFeeContext is like GetTransferFee but the request is bound to the given context
+func (c *Client) GetTransferFeeContext(ctx context.Context, currency string) (*TransferFeeResponse, error) {
+	q := url.Values{"seckey": {c.SecretKey}, "currency": {currency}}
+
+	resp := &TransferFeeResponse{}
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = TransferFeeResponse{}
+		return c.sendRequestAndParseResponse(ctx, "GET", c.buildURL(transferFeeURL)+"?"+q.Encode(), nil, resp)
+	}, nil)
+	return resp, err
+}
+
+// VerifyStatus verifies that response status is success
+// returns error otherwise
+func (resp *TransferResponse) VerifyStatus() error {
+	if resp.Status != "success" {
+		return fmt.Errorf("StatusVerificationFailed: expected success but got %s", resp.Status)
+	}
+	return nil
+}
+
+// VerifyCurrency verifies that the currency of the transfer matches the given currency
+// returns error otherwise
+func (resp *TransferResponse) VerifyCurrency(currency string) error {
+	if got := resp.Data.Currency; currency != got {
+		return fmt.Errorf("CurrencyVerificationFailed: expected %s but got %s", currency, got)
+	}
+	return nil
+}
+
+// VerifyAmount verifies that the transfer's amount is at least the given amount
+// and that it is in the given amount's currency if it has one
+// returns error otherwise
+func (resp *TransferResponse) VerifyAmount(amt Money) error {
+	return verifyAmount(amt, resp.Data.Amount, resp.Data.Currency)
+}
+
+// VerifyChargeResponseValue verifies that the transfer completed successfully
+// returns error otherwise
+func (resp *TransferResponse) VerifyChargeResponseValue() error {
+	if got := resp.Data.Status; got != "SUCCESSFUL" {
+		return fmt.Errorf("TransferStatusVerificationFailed: expected SUCCESSFUL but got %s", got)
+	}
+	return nil
+}
+
+// VerifyReference verifies that the reference of the transfer matches the given ref
+// returns error otherwise
+func (resp *TransferResponse) VerifyReference(ref string) error {
+	if got := resp.Data.Reference; ref != got {
+		return fmt.Errorf("ReferenceVerificationFailed: expected %s but got %s", ref, got)
+	}
+	return nil
+}
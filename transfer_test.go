@@ -0,0 +1,233 @@
+package ravepay
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type transferTestServer struct {
+	resp    string
+	path    string
+	query   map[string]string
+	payload map[string]interface{}
+}
+
+func (ts *transferTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ts.path = r.URL.Path
+	ts.query = map[string]string{}
+	for k := range r.URL.Query() {
+		ts.query[k] = r.URL.Query().Get(k)
+	}
+	ts.payload = nil
+	json.NewDecoder(r.Body).Decode(&ts.payload)
+	w.Write([]byte(ts.resp))
+}
+
+func TestClient_InitiateTransfer(t *testing.T) {
+	handler := &transferTestServer{resp: successfulTransferResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+
+	tests := []struct {
+		name        string
+		req         *TransferRequest
+		wantPayload map[string]interface{}
+	}{
+		{
+			name: "transfers to a bank account",
+			req: &TransferRequest{
+				Beneficiary: TransferBeneficiary{Bank: Bank{Code: "044", Name: "ACCESS BANK NIGERIA"}, AccountNumber: "0690000044"},
+				Amount:      NewMoney(50000, ""),
+				Currency:    "NGN",
+				Narration:   "payout",
+				Reference:   "transfer-ref-1",
+			},
+			wantPayload: map[string]interface{}{
+				"account_bank":   "044",
+				"account_number": "0690000044",
+				"amount":         float64(500),
+				"currency":       "NGN",
+				"narration":      "payout",
+				"reference":      "transfer-ref-1",
+				"seckey":         "sec-key",
+			},
+		},
+		{
+			name: "transfers to a mobile money wallet",
+			req: &TransferRequest{
+				Beneficiary: MobileMoneyBeneficiary("MPS", "233542773934", "Kwame Adew"),
+				Amount:      NewMoney(5000, ""),
+				Currency:    "KES",
+			},
+			wantPayload: map[string]interface{}{
+				"account_bank":     "MPS",
+				"account_number":   "233542773934",
+				"amount":           float64(50),
+				"beneficiary_name": "Kwame Adew",
+				"currency":         "KES",
+				"seckey":           "sec-key",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.InitiateTransfer(tt.req)
+			if err != nil {
+				t.Fatalf("Client.InitiateTransfer() error = %v", err)
+			}
+			if handler.path != createTransferURL {
+				t.Errorf("request path = %s, want %s", handler.path, createTransferURL)
+			}
+			if !reflect.DeepEqual(handler.payload, tt.wantPayload) {
+				t.Errorf("request payload = %v, want %v", handler.payload, tt.wantPayload)
+			}
+			if got.Data.ID != 4569 || got.Data.Status != "NEW" {
+				t.Errorf("Client.InitiateTransfer() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestClient_InitiateBulkTransfer(t *testing.T) {
+	handler := &transferTestServer{resp: successfulBulkTransferResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.InitiateBulkTransfer(&BulkTransferRequest{
+		Title: "june payouts",
+		Transfers: []TransferRequest{
+			{Beneficiary: TransferBeneficiary{Bank: Bank{Code: "044"}, AccountNumber: "0690000032"}, Amount: NewMoney(50000, ""), Currency: "NGN", Narration: "payout"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Client.InitiateBulkTransfer() error = %v", err)
+	}
+	if got.Data.ID != 1141 {
+		t.Errorf("Client.InitiateBulkTransfer() = %+v", got)
+	}
+
+	want := []interface{}{
+		map[string]interface{}{"Bank": "044", "Account Number": "0690000032", "Amount": float64(500), "Currency": "NGN", "Narration": "payout"},
+	}
+	if !reflect.DeepEqual(handler.payload["bulk_data"], want) || handler.path != createBulkTransferURL {
+		t.Errorf("request = %s %v, want bulk_data %v", handler.path, handler.payload, want)
+	}
+}
+
+func TestClient_GetTransfer(t *testing.T) {
+	handler := &transferTestServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+
+	handler.resp = transferListResponse
+	got, err := c.GetTransfer("transfer-ref-1")
+	if err != nil {
+		t.Fatalf("Client.GetTransfer() error = %v", err)
+	}
+	if handler.query["reference"] != "transfer-ref-1" || handler.query["seckey"] != "sec-key" {
+		t.Errorf("request query = %v", handler.query)
+	}
+	if errs := (&TxnVerificationChecklist{Amount: NewMoney(50000, ""), FlwRef: "transfer-ref-1", TransactionCurrency: "NGN"}).Verify(got); len(errs) != 0 {
+		t.Errorf("verifying transfer returned %v, want no errors", errs)
+	}
+
+	handler.resp = emptyTransferListResponse
+	if _, err := c.GetTransfer("unknown-ref"); !errors.Is(err, ErrTransferNotFound) {
+		t.Errorf("Client.GetTransfer() error = %v, want %v", err, ErrTransferNotFound)
+	}
+}
+
+func TestClient_ListTransfers(t *testing.T) {
+	handler := &transferTestServer{resp: transferListResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.ListTransfers(2, "successful")
+	if err != nil {
+		t.Fatalf("Client.ListTransfers() error = %v", err)
+	}
+	if handler.query["page"] != "2" || handler.query["status"] != "successful" {
+		t.Errorf("request query = %v", handler.query)
+	}
+	if len(got.Data.Transfers) != 1 || got.Data.PageInfo.TotalPages != 3 || !got.Data.PageInfo.HasNextPage() {
+		t.Errorf("Client.ListTransfers() = %+v", got.Data)
+	}
+}
+
+func TestClient_GetTransferFee(t *testing.T) {
+	handler := &transferTestServer{resp: transferFeeResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+	got, err := c.GetTransferFee("NGN")
+	if err != nil {
+		t.Fatalf("Client.GetTransferFee() error = %v", err)
+	}
+	if handler.path != transferFeeURL || handler.query["currency"] != "NGN" {
+		t.Errorf("request = %s %v", handler.path, handler.query)
+	}
+	if len(got.Data) != 1 || got.Data[0].Fee != NewMoney(4500, "") {
+		t.Errorf("Client.GetTransferFee() = %+v", got.Data)
+	}
+}
+
+func TestClient_getErrorsHideTheSecretKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"error","message":"Bad request"}`))
+	}))
+	defer server.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	const secretKey = "sec-key-in-query"
+	calls := []struct {
+		name string
+		call func(c *Client) error
+	}{
+		{"ListTransfers", func(c *Client) error { _, err := c.ListTransfers(1, "failed"); return err }},
+		{"GetTransferFee", func(c *Client) error { _, err := c.GetTransferFee("NGN"); return err }},
+		{"ListPaymentPlans", func(c *Client) error { _, err := c.ListPaymentPlans(1); return err }},
+		{"ListSubscriptions", func(c *Client) error { _, err := c.ListSubscriptions(1); return err }},
+		{"ListSubaccounts", func(c *Client) error { _, err := c.ListSubaccounts(1); return err }},
+		{"GetSubaccount", func(c *Client) error { _, err := c.GetSubaccount("RS_0A6C260E1A70934DE6EF2F8CEE46BBB3"); return err }},
+		{"VerifyBVN", func(c *Client) error { _, err := c.VerifyBVN("12345678901"); return err }},
+		{"LookupBIN", func(c *Client) error { _, err := c.LookupBIN("543889"); return err }},
+	}
+	for _, baseURL := range []string{server.URL, closed.URL} {
+		c := NewClient(WithKeys("pub-key", secretKey), WithBaseURL(baseURL))
+		for _, tt := range calls {
+			t.Run(tt.name, func(t *testing.T) {
+				err := tt.call(c)
+				if err == nil {
+					t.Fatalf("Client.%s() error = nil", tt.name)
+				}
+				if strings.Contains(err.Error(), secretKey) {
+					t.Errorf("Client.%s() error has the secret key: %v", tt.name, err)
+				}
+			})
+		}
+	}
+}
+
+var successfulTransferResponse = `{"status":"success","message":"TRANSFER-CREATED","data":{"id":4569,"account_number":"0690000044","bank_code":"044","fullname":"Mercedes Daniel","date_created":"2018-06-20T09:41:47.000Z","currency":"NGN","amount":500,"fee":45,"status":"NEW","reference":"transfer-ref-1","meta":null,"narration":"payout","complete_message":"","requires_approval":0,"is_approved":1,"bank_name":"ACCESS BANK NIGERIA"}}`
+
+var successfulBulkTransferResponse = `{"status":"success","message":"BULK-TRANSFER-CREATED","data":{"id":1141,"date_created":"2018-06-20T09:50:46.000Z","approver":"N/A"}}`
+
+var transferListResponse = `{"status":"success","message":"QUERIED-TRANSFERS","data":{"page_info":{"total":21,"current_page":2,"total_pages":3},"transfers":[{"id":4569,"account_number":"0690000044","bank_code":"044","fullname":"Mercedes Daniel","date_created":"2018-06-20T09:41:47.000Z","currency":"NGN","debit_currency":"NGN","amount":500,"fee":45,"status":"SUCCESSFUL","reference":"transfer-ref-1","meta":null,"narration":"payout","complete_message":"Successful","requires_approval":0,"is_approved":1,"bank_name":"ACCESS BANK NIGERIA"}]}}`
+
+var emptyTransferListResponse = `{"status":"success","message":"QUERIED-TRANSFERS","data":{"page_info":{"total":0,"current_page":0,"total_pages":0},"transfers":[]}}`
+
+var transferFeeResponse = `{"status":"success","message":"TRANSFER-FEES","data":[{"id":1,"fee_type":"value","currency":"NGN","fee":45,"entity":"all_banks"}]}`
@@ -1,8 +1,8 @@
 package ravepay
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 )
 
 // USSD is a type that encapsulates rave's ussd description
@@ -24,7 +24,7 @@ type USSD struct {
 // USSDPaymentInfo is the information necessary for completing mpesa payment
 type USSDPaymentInfo struct {
 	FlwRef string
-	Amount int
+	Amount Money
 }
 
 // ChargeURL is an implemenation of the Chargeable interface
@@ -56,11 +56,29 @@ func (c *USSD) BuildChargeRequestPayload(cReq *ChargeRequest) []byte {
 	}{c, cReq}
 	b, err := json.Marshal(payload)
 	if err != nil {
-		log.Println("couldn't marshal payload: ", err)
+		logf(context.Background(), LogLevelError, "couldn't marshal payload", "err", err)
 	}
 	return b
 }
 
+// ussdMarkets are the countries and currencies ussd charges accept
+var ussdMarkets = []market{{"NG", "NGN"}}
+
+// Validate checks the ussd charge has a bank and is in NGN from NG
+func (c *USSD) Validate() error {
+	var errs ValidationErrors
+	if c.AccountBank == "" {
+		errs = append(errs, &ValidationError{Field: "accountbank", Message: "is required"})
+	}
+	errs = errs.add("", validateMarket("ussd", c.Country, c.Currency, ussdMarkets))
+	return errs.err()
+}
+
+// validateCharge checks the charge amount is valid in NGN
+func (c *USSD) validateCharge(cReq *ChargeRequest) error {
+	return validateAmountPrecision(cReq.Amount, c.Currency)
+}
+
 // USSDPaymentInstruction parses the given charge response
 // and returns the payment info for completing the payment
 func USSDPaymentInstruction(cr *ChargeResponse) *USSDPaymentInfo {
@@ -50,13 +50,13 @@ func TestUSSDPaymentInstruction(t *testing.T) {
 			args: args{
 				cr: &ChargeResponse{
 					Data: chargeResponseData{
-						Amount: 900,
+						Amount: NewMoney(90000, ""),
 						FlwRef: "some-ref",
 					},
 				},
 			},
 			want: &USSDPaymentInfo{
-				Amount: 900,
+				Amount: NewMoney(90000, ""),
 				FlwRef: "some-ref",
 			},
 		},
@@ -0,0 +1,37 @@
+package ravepay
+
+import "net/mail"
+
+// Validator is implemented by requests that can be checked before they are sent to rave
+// Validate returns ValidationErrors listing every invalid field, or nil if the request is valid
+// The package validates requests automatically before sending them
+type Validator interface {
+	Validate() error
+}
+
+// validEmail reports whether the email is a bare address e.g user@example.com
+func validEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// validPhoneNumber reports whether the phone number is 7 to 15 digits with an optional leading +
+func validPhoneNumber(phone string) bool {
+	if len(phone) > 0 && phone[0] == '+' {
+		phone = phone[1:]
+	}
+	for n := 7; n <= 15; n++ {
+		if isDigits(phone, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRequest validates the request if it is a Validator
+func validateRequest(r interface{}) error {
+	if v, ok := r.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
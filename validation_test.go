@@ -0,0 +1,154 @@
+package ravepay
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func fieldsOf(err error) []string {
+	var vErrs ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return nil
+	}
+	fields := make([]string, len(vErrs))
+	for i, e := range vErrs {
+		fields[i] = e.Field
+	}
+	return fields
+}
+
+func TestValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		v          Validator
+		wantFields []string
+	}{
+		{
+			name: "accepts a valid charge request",
+			v:    &ChargeRequest{TxRef: "MXX-ASC-4578", Amount: NewMoney(30000, "NGN"), Email: "tester@flutter.co", PhoneNumber: "+2348012345678"},
+		},
+		{
+			name:       "lists every invalid field of a charge request",
+			v:          &ChargeRequest{Amount: NewMoney(-100, "NGN"), Email: "tester@", PhoneNumber: "0801-234"},
+			wantFields: []string{"txRef", "amount", "email", "phonenumber"},
+		},
+		{
+			name: "accepts a valid tokenized charge",
+			v:    &TokenizedChargeRequest{Token: "flw-t0-1", TxRef: "MC-1", Amount: NewMoney(30000, ""), Currency: "NGN", Email: "user@example.com"},
+		},
+		{
+			name:       "lists every invalid field of a tokenized charge",
+			v:          &TokenizedChargeRequest{Amount: NewMoney(0, ""), Currency: "BTC", Email: "user@"},
+			wantFields: []string{"token", "txRef", "amount", "email", "currency"},
+		},
+		{
+			name:       "lists every invalid field of a card",
+			v:          &Card{CardNo: "5438898014560228", Expirymonth: "13", Cvv: "12", Currency: "BTC"},
+			wantFields: []string{"cardno", "expirymonth", "expiryyear", "cvv", "currency"},
+		},
+		{
+			name:       "requires the bank and account number of an account",
+			v:          &Account{AccountNumber: "069-000", Country: "NG"},
+			wantFields: []string{"account_bank", "account_number"},
+		},
+		{
+			name:       "requires the network of ghana mobile money",
+			v:          &MobileMoneyGH{Currency: "GHS", Country: "GH"},
+			wantFields: []string{"network"},
+		},
+		{
+			name:       "requires the bank of a ussd charge",
+			v:          &USSD{Currency: "NGN"},
+			wantFields: []string{"accountbank"},
+		},
+		{
+			name:       "lists every invalid field of a fee request",
+			v:          &GetFeeRequest{Card6: "5438"},
+			wantFields: []string{"amount", "currency", "card6"},
+		},
+		{
+			name:       "lists every invalid field of forex params",
+			v:          &ForexParams{OriginCurrency: "BTC", Amount: &Money{}},
+			wantFields: []string{"origin_currency", "destination_currency", "amount"},
+		},
+		{
+			name: "accepts forex params without an amount",
+			v:    &ForexParams{OriginCurrency: "USD", DestinationCurrency: "NGN"},
+		},
+		{
+			name:       "lists every invalid field of a verification checklist",
+			v:          &TxnVerificationChecklist{},
+			wantFields: []string{"flw_ref", "amount", "currency"},
+		},
+		{
+			name: "accepts a checklist with a txref",
+			v:    &TxnVerificationChecklist{Txref: "MXX-ASC-4578", Amount: NewMoney(30000, ""), TransactionCurrency: "NGN"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.v.Validate()
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrValidation)
+			}
+			if got := fieldsOf(err); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	err := ValidationErrors{}.
+		add("", &ValidationError{Field: "txRef", Message: "is required"}).
+		add("", ValidationErrors{{Field: "cvv", Message: "must be 3 digits"}}).
+		add("subaccounts", errors.New("splits exceed the amount")).
+		add("amount", nil).
+		err()
+
+	want := "ValidationError: txRef is required; cvv must be 3 digits; subaccounts splits exceed the amount"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidationErrors.Error() = %v, want %s", err, want)
+	}
+	var vErr *ValidationError
+	if !errors.Is(err, ErrValidation) || !errors.As(err, &vErr) || vErr.Field != "txRef" {
+		t.Errorf("ValidationErrors doesn't unwrap to its first field's error: %v", vErr)
+	}
+	if err := (ValidationErrors{}).err(); err != nil {
+		t.Errorf("ValidationErrors{}.err() = %v, want nil", err)
+	}
+}
+
+func TestClient_validatesRequests(t *testing.T) {
+	handler := &retryTestServer{resp: successfulCardChargeResponse}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	c := NewClient(WithKeys("pub-key", "sec-key"), WithBaseURL(server.URL))
+
+	if _, err := c.Charge(&ChargeRequest{}, &Card{ChargeCardURL: server.URL}); len(fieldsOf(err)) != 7 {
+		t.Errorf("Client.Charge() error = %v, want the charge request's and card's invalid fields", err)
+	}
+	if _, err := c.GetFee(&GetFeeRequest{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Client.GetFee() error = %v, want %v", err, ErrValidation)
+	}
+	if _, err := c.ForexRate(&ForexParams{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Client.ForexRate() error = %v, want %v", err, ErrValidation)
+	}
+	if _, errs := c.VerifyTransaction(&TxnVerificationChecklist{}); len(errs) != 1 || !errors.Is(errs[0], ErrValidation) {
+		t.Errorf("Client.VerifyTransaction() errs = %v, want %v", errs, ErrValidation)
+	}
+	if _, errs := c.VerifyXRequeryTransaction(&TxnVerificationChecklist{}); len(errs) != 1 || !errors.Is(errs[0], ErrValidation) {
+		t.Errorf("Client.VerifyXRequeryTransaction() errs = %v, want %v", errs, ErrValidation)
+	}
+	if handler.chargeAttempts != 0 || handler.lookups != 0 {
+		t.Errorf("invalid requests made %d requests, want none", handler.chargeAttempts+handler.lookups)
+	}
+}
@@ -1,11 +1,16 @@
 package ravepay
 
+import (
+	"context"
+	"fmt"
+)
+
 // Verifiable is an abstract representation of any rave resources that can be verified
 // verified here
 type Verifiable interface {
 	VerifyStatus() error
 	VerifyCurrency(string) error
-	VerifyAmount(int) error
+	VerifyAmount(Money) error
 	VerifyChargeResponseValue() error
 	VerifyReference(string) error
 }
@@ -14,7 +19,7 @@ type Verifiable interface {
 // It includes details of the payment to verify
 // and the verification response
 type TxnVerificationChecklist struct {
-	Amount              int    `json:"-"`
+	Amount              Money  `json:"-"`
 	FlwRef              string `json:"flw_ref,omitempty"` // for some weird reason, this just had to be different from below
 	Flwref              string `json:"flwref,omitempty"`  // for some weird reason, this just had to be different from above
 	LastAttempt         string `json:"last_attempt,omitempty"`
@@ -27,6 +32,45 @@ type TxnVerificationChecklist struct {
 	Txref               string `json:"txref,omitempty"`  // for some weird reason, this just had to be different from below
 	// Done tracks whether verification has been attempted. It starts out false for new objects and changes to true after #verify is called on the object
 	Done bool `json:"-"`
+	// Policy configures the checks the transaction is verified with, the zero policy is rave's recommended checks
+	Policy VerificationPolicy `json:"-"`
+}
+
+// Redacted returns a copy of the checklist with its secret key masked
+// It is safe to log or encode as json, unlike the checklist itself whose json is the verification request
+func (tvc TxnVerificationChecklist) Redacted() TxnVerificationChecklist {
+	tvc.SECKEY = redactSecret(tvc.SECKEY)
+	return tvc
+}
+
+// txnVerificationChecklist has the fields of TxnVerificationChecklist without its methods
+// for formatting it without recursing into String
+type txnVerificationChecklist TxnVerificationChecklist
+
+// String formats the checklist with its secret key masked
+func (tvc TxnVerificationChecklist) String() string {
+	return fmt.Sprintf("%+v", txnVerificationChecklist(tvc.Redacted()))
+}
+
+// GoString formats the checklist in go syntax with its secret key masked
+func (tvc TxnVerificationChecklist) GoString() string {
+	return goString("TxnVerificationChecklist", txnVerificationChecklist(tvc.Redacted()))
+}
+
+// Validate checks the checklist has a reference to look the transaction up by
+// and the amount and currency the transaction is verified against
+func (tvc *TxnVerificationChecklist) Validate() error {
+	var errs ValidationErrors
+	if tvc.FlwRef == "" && tvc.Flwref == "" && tvc.TxRef == "" && tvc.Txref == "" {
+		errs = append(errs, &ValidationError{Field: "flw_ref", Message: "or tx_ref is required"})
+	}
+	if tvc.Amount.Minor <= 0 {
+		errs = append(errs, &ValidationError{Field: "amount", Message: "must be positive"})
+	}
+	if tvc.TransactionCurrency == "" {
+		errs = append(errs, &ValidationError{Field: "currency", Message: "is required"})
+	}
+	return errs.err()
 }
 
 // VerifyTransaction sends a rave Transaction verfication request and then verfies the response
@@ -35,17 +79,24 @@ type TxnVerificationChecklist struct {
 // it also marks the verification as done
 // If the verification URL is not set, it set's it to the default from config
 func (tvc *TxnVerificationChecklist) VerifyTransaction() (*TxnVerificationResponse, []error) {
-	// TODO: Validate checklist???
-	// Make request to endpoint
+	return tvc.VerifyTransactionContext(context.Background())
+}
 
-	resp := &TxnVerificationResponse{}
-	if tvc.VerificationURL == "" {
-		tvc.VerificationURL = buildURL(txnVerificationURL)
-	}
+// VerifyTransactionContext is like VerifyTransaction but the request is bound to the given context
+func (tvc *TxnVerificationChecklist) VerifyTransactionContext(ctx context.Context) (*TxnVerificationResponse, []error) {
+	return defaultClient().VerifyTransactionContext(ctx, tvc)
+}
 
-	err := sendRequestAndParseResponse("POST", tvc.VerificationURL, tvc, resp)
-	tvc.Done = true
+// VerifyTransaction sends a rave Transaction verfication request for the given checklist and then verfies the response
+// it also marks the verification as done
+// If the verification URL is not set, it set's it to the client's default
+func (c *Client) VerifyTransaction(tvc *TxnVerificationChecklist) (*TxnVerificationResponse, []error) {
+	return c.VerifyTransactionContext(context.Background(), tvc)
+}
 
+// VerifyTransactionContext is like VerifyTransaction but the request is bound to the given context
+func (c *Client) VerifyTransactionContext(ctx context.Context, tvc *TxnVerificationChecklist) (*TxnVerificationResponse, []error) {
+	resp, err := c.verifyTransaction(ctx, tvc)
 	if err != nil {
 		return resp, []error{err}
 	}
@@ -54,22 +105,53 @@ func (tvc *TxnVerificationChecklist) VerifyTransaction() (*TxnVerificationRespon
 	return resp, errs
 }
 
+// verifyTransaction sends the verification request of the checklist and marks the verification as done
+func (c *Client) verifyTransaction(ctx context.Context, tvc *TxnVerificationChecklist) (*TxnVerificationResponse, error) {
+	if err := tvc.Validate(); err != nil {
+		return &TxnVerificationResponse{}, err
+	}
+
+	resp := &TxnVerificationResponse{}
+	if tvc.VerificationURL == "" {
+		tvc.VerificationURL = c.buildURL(txnVerificationURL)
+	}
+	if tvc.SECKEY == "" {
+		tvc.SECKEY = c.SecretKey
+	}
+
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = TxnVerificationResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", c.rebaseURL(tvc.VerificationURL), tvc, resp)
+	}, nil)
+	tvc.Done = true
+	return resp, err
+}
+
 // VerifyXRequeryTransaction sends a rave XRequery transaction verification request and then verifies the response
 // It validates that verification checklist contains all the required information for making the request
 // it also marks the verification as done
 // https://flutterwavedevelopers.readme.io/v1.0/reference#xrequery-transaction-verification
 // If the verification URL is not set, it set's it to the default from config
 func (tvc *TxnVerificationChecklist) VerifyXRequeryTransaction() (*XRQTxnVerificationResponse, []error) {
-	// TODO: Validate checklist???
-	// TODO: XRQT could return a data array depending on the query args. Handle that possibility
-	resp := &XRQTxnVerificationResponse{}
-	if tvc.VerificationURL == "" {
-		tvc.VerificationURL = buildURL(txnVerificationRequeryURL)
-	}
+	return tvc.VerifyXRequeryTransactionContext(context.Background())
+}
 
-	err := sendRequestAndParseResponse("POST", tvc.VerificationURL, tvc, resp)
-	tvc.Done = true
+// VerifyXRequeryTransactionContext is like VerifyXRequeryTransaction but the request is bound to the given context
+func (tvc *TxnVerificationChecklist) VerifyXRequeryTransactionContext(ctx context.Context) (*XRQTxnVerificationResponse, []error) {
+	return defaultClient().VerifyXRequeryTransactionContext(ctx, tvc)
+}
 
+// VerifyXRequeryTransaction sends a rave XRequery transaction verification request for the given checklist
+// and then verifies the response
+// it also marks the verification as done
+// If the verification URL is not set, it set's it to the client's default
+func (c *Client) VerifyXRequeryTransaction(tvc *TxnVerificationChecklist) (*XRQTxnVerificationResponse, []error) {
+	return c.VerifyXRequeryTransactionContext(context.Background(), tvc)
+}
+
+// VerifyXRequeryTransactionContext is like VerifyXRequeryTransaction but the request is bound to the given context
+func (c *Client) VerifyXRequeryTransactionContext(ctx context.Context, tvc *TxnVerificationChecklist) (*XRQTxnVerificationResponse, []error) {
+	resp, err := c.verifyXRequeryTransaction(ctx, tvc)
 	if err != nil {
 		return resp, []error{err}
 	}
@@ -78,32 +160,30 @@ func (tvc *TxnVerificationChecklist) VerifyXRequeryTransaction() (*XRQTxnVerific
 	return resp, errs
 }
 
-// Verify performs the rave's recommended verification check on the verifiable resource
-// It returns an array of error for verfications that fail (if any)
-// and marks the verification as Done
-func (tvc *TxnVerificationChecklist) Verify(v Verifiable) []error {
-	errs := []error{}
-
-	if err := v.VerifyCurrency(tvc.TransactionCurrency); err != nil {
-		errs = append(errs, err)
-	}
-
-	if err := v.VerifyAmount(tvc.Amount); err != nil {
-		errs = append(errs, err)
+// verifyXRequeryTransaction sends the xrequery verification request of the checklist and marks the verification as done
+func (c *Client) verifyXRequeryTransaction(ctx context.Context, tvc *TxnVerificationChecklist) (*XRQTxnVerificationResponse, error) {
+	if err := tvc.Validate(); err != nil {
+		return &XRQTxnVerificationResponse{}, err
 	}
-
-	// Todo solve Flwref vs FlwRef
-	if err := v.VerifyReference(tvc.FlwRef); err != nil {
-		errs = append(errs, err)
+	resp := &XRQTxnVerificationResponse{}
+	if tvc.VerificationURL == "" {
+		tvc.VerificationURL = c.buildURL(txnVerificationRequeryURL)
 	}
-
-	if err := v.VerifyStatus(); err != nil {
-		errs = append(errs, err)
+	if tvc.SECKEY == "" {
+		tvc.SECKEY = c.SecretKey
 	}
 
-	if err := v.VerifyChargeResponseValue(); err != nil {
-		errs = append(errs, err)
-	}
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = XRQTxnVerificationResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", c.rebaseURL(tvc.VerificationURL), tvc, resp)
+	}, nil)
+	tvc.Done = true
+	return resp, err
+}
 
-	return errs
+// Verify performs the rave's recommended verification check on the verifiable resource
+// and the checks of the checklist's policy, see Report
+// It returns an array of error for verfications that fail (if any)
+func (tvc *TxnVerificationChecklist) Verify(v Verifiable) []error {
+	return tvc.Report(v).Errors()
 }
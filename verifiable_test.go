@@ -1,9 +1,11 @@
 package ravepay
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -17,7 +19,7 @@ func (tv testVerifiable) VerifyStatus() error {
 func (tv testVerifiable) VerifyCurrency(string) error {
 	return tv.currencyErr
 }
-func (tv testVerifiable) VerifyAmount(int) error {
+func (tv testVerifiable) VerifyAmount(Money) error {
 	return tv.amountErr
 }
 func (tv testVerifiable) VerifyChargeResponseValue() error {
@@ -110,7 +112,7 @@ func TestTxnVerificationChecklist_VerifyTransaction(t *testing.T) {
 	defer server.Close()
 
 	type fields struct {
-		Amount              int
+		Amount              Money
 		FlwRef              string
 		TransactionCurrency string
 	}
@@ -125,7 +127,7 @@ func TestTxnVerificationChecklist_VerifyTransaction(t *testing.T) {
 			name:     "returns error if verification fails",
 			respBody: noTransactionFoundVerifyPaymentResponse,
 			fields: fields{
-				Amount:              300,
+				Amount:              NewMoney(30000, ""),
 				FlwRef:              "FLW-MOCK-09805abc71c5eebf80bb899183475fe3",
 				TransactionCurrency: "NGN",
 			},
@@ -138,18 +140,21 @@ func TestTxnVerificationChecklist_VerifyTransaction(t *testing.T) {
 				},
 			},
 			want1: []error{
-				fmt.Errorf("CurrencyVerificationFailed: expected NGN but got "),
-				fmt.Errorf("AmountVerificationFailed: expected 300 but got 0"),
-				fmt.Errorf("FlwRefVerificationFailed: expected FLW-MOCK-09805abc71c5eebf80bb899183475fe3 but got "),
-				fmt.Errorf("StatusVerificationFailed: expected success but got error"),
-				fmt.Errorf("ChargeResponseVerificationFailed: expected 00 or 0 but got "),
+				&APIError{
+					HTTPStatus: 200,
+					Status:     "error",
+					Message:    "No transaction found",
+					Method:     "POST",
+					Endpoint:   server.URL,
+					Body:       []byte(noTransactionFoundVerifyPaymentResponse),
+				},
 			},
 		},
 		{
 			name:     "returns no error if account verification succeeds",
 			respBody: successfulAccountVerifyPaymentResponse,
 			fields: fields{
-				Amount:              100,
+				Amount:              NewMoney(10000, ""),
 				FlwRef:              "ACHG-1512550576634",
 				TransactionCurrency: "NGN",
 			},
@@ -167,9 +172,9 @@ func TestTxnVerificationChecklist_VerifyTransaction(t *testing.T) {
 					DeviceFingerprint:    "689de87638deca2ca28dc8bb16f39581",
 					Cycle:                "one-time",
 					Narration:            "Synergy Group",
-					Amount:               100,
+					Amount:               NewMoney(10000, ""),
 					Merchantbearsfee:     1,
-					ChargedAmount:        100,
+					ChargedAmount:        NewMoney(10000, ""),
 					TransactionCurrency:  "NGN",
 					PaymentEntity:        "account",
 					PaymentID:            "16",
@@ -213,7 +218,7 @@ func TestTxnVerificationChecklist_VerifyTransaction(t *testing.T) {
 			name:     "returns no error if card verification succeeds",
 			respBody: successfulCardVerifyPaymentResponse,
 			fields: fields{
-				Amount:              300,
+				Amount:              NewMoney(30000, ""),
 				FlwRef:              "FLW-MOCK-09805abc71c5eebf80bb899183475fe3",
 				TransactionCurrency: "NGN",
 			},
@@ -233,9 +238,9 @@ func TestTxnVerificationChecklist_VerifyTransaction(t *testing.T) {
 					DeviceFingerprint:    "352693081974640",
 					Cycle:                "one-time",
 					Narration:            "FLW-PBF CARD Transaction ",
-					Amount:               300,
+					Amount:               NewMoney(30000, ""),
 					Merchantbearsfee:     1,
-					ChargedAmount:        300,
+					ChargedAmount:        NewMoney(30000, ""),
 					TransactionCurrency:  "NGN",
 					PaymentEntity:        "card",
 					PaymentID:            "356",
@@ -294,7 +299,7 @@ func TestTxnVerificationChecklist_VerifyTransaction(t *testing.T) {
 	}
 
 	// Test it sets a verification URL if empty
-	tvc := &TxnVerificationChecklist{VerificationURL: ""}
+	tvc := &TxnVerificationChecklist{FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", Amount: NewMoney(30000, ""), TransactionCurrency: "NGN", VerificationURL: ""}
 	baseURL = server.URL
 	tvc.VerifyTransaction()
 
@@ -309,7 +314,7 @@ func TestTxnVerificationChecklist_VerifyXRequeryTransaction(t *testing.T) {
 	defer server.Close()
 
 	type fields struct {
-		Amount              int
+		Amount              Money
 		Flwref              string
 		LastAttempt         string
 		OnlySuccessful      string
@@ -327,7 +332,7 @@ func TestTxnVerificationChecklist_VerifyXRequeryTransaction(t *testing.T) {
 		{
 			name: "returns errors if verification fails",
 			fields: fields{
-				Amount:              10000,
+				Amount:              NewMoney(1000000, ""),
 				Flwref:              "FLW-MOCK-09805abc71c5eebf80bb899183475fe3",
 				LastAttempt:         "1",
 				OnlySuccessful:      "1",
@@ -339,7 +344,7 @@ func TestTxnVerificationChecklist_VerifyXRequeryTransaction(t *testing.T) {
 			want: &XRQTxnVerificationResponse{
 				Status:  "success",
 				Message: "Tx Fetched",
-				Data: xRQTxnVerificationResponseData{
+				Data: XRQTxnAttempt{
 					Accountid:                     134,
 					Acctalias:                     "temi",
 					Acctbearsfeeattransactiontime: 1,
@@ -349,12 +354,12 @@ func TestTxnVerificationChecklist_VerifyXRequeryTransaction(t *testing.T) {
 					Acctisliveapproved:            0,
 					Acctparent:                    1,
 					Acctvpcmerchant:               "N/A",
-					Amount:                        8150,
-					Amountsettledforthistransaction: 8150,
+					Amount:                        NewMoney(815000, ""),
+					Amountsettledforthistransaction: NewMoney(815000, ""),
 					Authmodel:                       "PIN",
 					Authurl:                         "http://flw-pms-dev.eu-west-1.elasticbeanstalk.com/mockvbvpage?ref=FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88&code=00&message=Approved. Successful&receiptno=RN1504378687808",
 					Chargecode:                      "00",
-					Chargedamount:                   8150,
+					Chargedamount:                   NewMoney(815000, ""),
 					Chargemessage:                   "Success-Pending-otp-validation",
 					Chargetype:                      "normal",
 					Created:                         "2017-09-02T18:58:07.000Z",
@@ -401,7 +406,7 @@ func TestTxnVerificationChecklist_VerifyXRequeryTransaction(t *testing.T) {
 		{
 			name: "returns no errors if verification succeeds",
 			fields: fields{
-				Amount:              8150,
+				Amount:              NewMoney(815000, ""),
 				Flwref:              "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88",
 				LastAttempt:         "1",
 				OnlySuccessful:      "1",
@@ -413,7 +418,7 @@ func TestTxnVerificationChecklist_VerifyXRequeryTransaction(t *testing.T) {
 			want: &XRQTxnVerificationResponse{
 				Status:  "success",
 				Message: "Tx Fetched",
-				Data: xRQTxnVerificationResponseData{
+				Data: XRQTxnAttempt{
 					Accountid:                     134,
 					Acctalias:                     "temi",
 					Acctbearsfeeattransactiontime: 1,
@@ -423,12 +428,12 @@ func TestTxnVerificationChecklist_VerifyXRequeryTransaction(t *testing.T) {
 					Acctisliveapproved:            0,
 					Acctparent:                    1,
 					Acctvpcmerchant:               "N/A",
-					Amount:                        8150,
-					Amountsettledforthistransaction: 8150,
+					Amount:                        NewMoney(815000, ""),
+					Amountsettledforthistransaction: NewMoney(815000, ""),
 					Authmodel:                       "PIN",
 					Authurl:                         "http://flw-pms-dev.eu-west-1.elasticbeanstalk.com/mockvbvpage?ref=FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88&code=00&message=Approved. Successful&receiptno=RN1504378687808",
 					Chargecode:                      "00",
-					Chargedamount:                   8150,
+					Chargedamount:                   NewMoney(815000, ""),
 					Chargemessage:                   "Success-Pending-otp-validation",
 					Chargetype:                      "normal",
 					Created:                         "2017-09-02T18:58:07.000Z",
@@ -496,7 +501,7 @@ func TestTxnVerificationChecklist_VerifyXRequeryTransaction(t *testing.T) {
 	}
 
 	// Test it sets a verification URL if empty
-	tvc := &TxnVerificationChecklist{VerificationURL: ""}
+	tvc := &TxnVerificationChecklist{FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", Amount: NewMoney(30000, ""), TransactionCurrency: "NGN", VerificationURL: ""}
 	baseURL = server.URL
 	tvc.VerifyXRequeryTransaction()
 
@@ -504,3 +509,19 @@ func TestTxnVerificationChecklist_VerifyXRequeryTransaction(t *testing.T) {
 		t.Errorf("expected TxnVerificationChecklist.VerifyTransaction().VerificationURL not to be mepty")
 	}
 }
+
+func TestTxnVerificationChecklist_Redacted(t *testing.T) {
+	tvc := TxnVerificationChecklist{FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", SECKEY: "FLWSECK-e6db11d1f8a6208de8cb2f94e293450e-X"}
+
+	b, _ := json.Marshal(tvc.Redacted())
+	for _, got := range []string{fmt.Sprint(tvc), fmt.Sprintf("%+v", &tvc), fmt.Sprintf("%#v", tvc), string(b)} {
+		if strings.Contains(got, "e6db11d1f8a6208de8cb2f94e293450e") || !strings.Contains(got, tvc.FlwRef) {
+			t.Errorf("formatted checklist %s, want the secret key masked", got)
+		}
+	}
+
+	b, _ = json.Marshal(tvc)
+	if !strings.Contains(string(b), tvc.SECKEY) {
+		t.Errorf("the verification request %s doesn't have the secret key", b)
+	}
+}
@@ -0,0 +1,308 @@
+package ravepay
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// VerifyBy is the reference a transaction is verified by
+type VerifyBy string
+
+const (
+	// VerifyByFlwRef verifies the transaction by rave's reference
+	VerifyByFlwRef VerifyBy = "flw_ref"
+	// VerifyByTxRef verifies the transaction by the merchant's reference
+	VerifyByTxRef VerifyBy = "tx_ref"
+)
+
+// the names of the checks of a VerificationReport
+const (
+	CheckCurrency       = "currency"
+	CheckAmount         = "amount"
+	CheckReference      = "reference"
+	CheckStatus         = "status"
+	CheckChargeResponse = "charge_response"
+	CheckFraudStatus    = "fraud_status"
+	CheckPaymentEntity  = "payment_entity"
+)
+
+// VerificationPolicy configures the checks a transaction is verified with
+// The zero policy is rave's recommended verification: the reference, currency, status and charge response match
+// and the amount is at least the expected amount
+type VerificationPolicy struct {
+	// By is the reference the transaction is verified by
+	// If it's empty the flw ref is used if the checklist has one, otherwise the tx ref
+	By VerifyBy
+	// ExactAmount requires the amount to be exactly the expected amount instead of at least it
+	ExactAmount bool
+	// ChargedAmount compares the amount charged the customer i.e including fees, instead of the transaction's amount
+	ChargedAmount bool
+	// FraudStatuses are the fraud statuses allowed e.g ok, any fraud status is allowed if it's empty
+	FraudStatuses []string
+	// PaymentEntity is the payment entity required e.g card or account, any entity is allowed if it's empty
+	PaymentEntity string
+}
+
+// VerificationDetails are the details of a transaction that are checked against a VerificationPolicy
+type VerificationDetails struct {
+	Status        string
+	FlwRef        string
+	TxRef         string
+	Currency      string
+	Amount        Money
+	ChargedAmount Money
+	ChargeCode    string
+	FraudStatus   string
+	PaymentEntity string
+}
+
+// VerifiableTransaction is a Verifiable transaction whose details can be checked against a VerificationPolicy
+type VerifiableTransaction interface {
+	Verifiable
+	VerificationDetails() VerificationDetails
+}
+
+// VerificationCheck is the outcome of a check of the verification
+type VerificationCheck struct {
+	Name     string `json:"name"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Passed   bool   `json:"passed"`
+	// Err is why the check failed, it's nil if it passed
+	Err error `json:"-"`
+}
+
+// String describes the check and its outcome e.g "amount: expected 3000 got 2000 (failed)"
+func (c VerificationCheck) String() string {
+	outcome := "passed"
+	if !c.Passed {
+		outcome = "failed"
+	}
+	return fmt.Sprintf("%s: expected %s got %s (%s)", c.Name, c.Expected, c.Actual, outcome)
+}
+
+// VerificationReport lists the checks a transaction was verified with and their outcomes
+type VerificationReport struct {
+	Checks []VerificationCheck `json:"checks"`
+}
+
+// Passed reports whether every check passed
+func (r *VerificationReport) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the checks that failed
+func (r *VerificationReport) Failed() []VerificationCheck {
+	var failed []VerificationCheck
+	for _, c := range r.Checks {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Check returns the check with the given name, it's false if the report doesn't have it
+func (r *VerificationReport) Check(name string) (VerificationCheck, bool) {
+	for _, c := range r.Checks {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return VerificationCheck{}, false
+}
+
+// Errors returns the errors of the checks that failed
+func (r *VerificationReport) Errors() []error {
+	errs := []error{}
+	for _, c := range r.Failed() {
+		errs = append(errs, c.Err)
+	}
+	return errs
+}
+
+func (r *VerificationReport) add(name, expected, actual string, err error) {
+	r.Checks = append(r.Checks, VerificationCheck{Name: name, Expected: expected, Actual: actual, Passed: err == nil, Err: err})
+}
+
+// reference returns the reference the policy verifies the transaction by and its value in the checklist
+func (tvc *TxnVerificationChecklist) reference() (VerifyBy, string) {
+	flwRef, txRef := firstNonEmpty(tvc.FlwRef, tvc.Flwref), firstNonEmpty(tvc.TxRef, tvc.Txref)
+	switch tvc.Policy.By {
+	case VerifyByTxRef:
+		return VerifyByTxRef, txRef
+	case VerifyByFlwRef:
+		return VerifyByFlwRef, flwRef
+	}
+	if flwRef == "" && txRef != "" {
+		return VerifyByTxRef, txRef
+	}
+	return VerifyByFlwRef, flwRef
+}
+
+// Report checks the verifiable resource against the checklist and its policy
+// Only verifiable transactions can be checked against the policy, other resources get rave's recommended checks
+func (tvc *TxnVerificationChecklist) Report(v Verifiable) *VerificationReport {
+	t, ok := v.(VerifiableTransaction)
+	if !ok {
+		return tvc.verifiableReport(v)
+	}
+
+	r := &VerificationReport{}
+	p := tvc.Policy
+	d := t.VerificationDetails()
+
+	r.add(CheckCurrency, tvc.TransactionCurrency, d.Currency, v.VerifyCurrency(tvc.TransactionCurrency))
+
+	amount, amountField := d.Amount, "amount"
+	if p.ChargedAmount {
+		amount, amountField = d.ChargedAmount, "charged amount"
+	}
+	var err error
+	if p.ExactAmount {
+		err = verifyExactAmount(tvc.Amount, amount, d.Currency)
+	} else {
+		err = verifyAmount(tvc.Amount, amount, d.Currency)
+	}
+	if err != nil && p.ChargedAmount {
+		err = fmt.Errorf("%s (%s)", err, amountField)
+	}
+	expected := tvc.Amount.String()
+	if !p.ExactAmount {
+		expected = "at least " + expected
+	}
+	r.add(CheckAmount, expected, amount.String(), err)
+
+	by, ref := tvc.reference()
+	gotRef := d.FlwRef
+	err = nil
+	if by == VerifyByTxRef {
+		gotRef = d.TxRef
+		if ref != gotRef {
+			err = fmt.Errorf("TxRefVerificationFailed: expected %s but got %s", ref, gotRef)
+		}
+	} else if ref != gotRef {
+		err = fmt.Errorf("FlwRefVerificationFailed: expected %s but got %s", ref, gotRef)
+	}
+	r.add(CheckReference, ref, gotRef, err)
+
+	r.add(CheckStatus, "success", d.Status, v.VerifyStatus())
+	r.add(CheckChargeResponse, "00 or 0", d.ChargeCode, v.VerifyChargeResponseValue())
+
+	if len(p.FraudStatuses) > 0 {
+		err = nil
+		if !containsFold(p.FraudStatuses, d.FraudStatus) {
+			err = fmt.Errorf("FraudStatusVerificationFailed: expected %s but got %s", strings.Join(p.FraudStatuses, " or "), d.FraudStatus)
+		}
+		r.add(CheckFraudStatus, strings.Join(p.FraudStatuses, " or "), d.FraudStatus, err)
+	}
+	if p.PaymentEntity != "" {
+		err = nil
+		if !strings.EqualFold(p.PaymentEntity, d.PaymentEntity) {
+			err = fmt.Errorf("PaymentEntityVerificationFailed: expected %s but got %s", p.PaymentEntity, d.PaymentEntity)
+		}
+		r.add(CheckPaymentEntity, p.PaymentEntity, d.PaymentEntity, err)
+	}
+	return r
+}
+
+// verifiableReport checks the resource with its own Verifiable checks, their actual values aren't known
+// The policy's extra checks fail as they can't be made
+func (tvc *TxnVerificationChecklist) verifiableReport(v Verifiable) *VerificationReport {
+	r := &VerificationReport{}
+	_, ref := tvc.reference()
+	r.add(CheckCurrency, tvc.TransactionCurrency, "", v.VerifyCurrency(tvc.TransactionCurrency))
+	r.add(CheckAmount, "at least "+tvc.Amount.String(), "", v.VerifyAmount(tvc.Amount))
+	r.add(CheckReference, ref, "", v.VerifyReference(ref))
+	r.add(CheckStatus, "success", "", v.VerifyStatus())
+	r.add(CheckChargeResponse, "00 or 0", "", v.VerifyChargeResponseValue())
+
+	p := tvc.Policy
+	if p.By == VerifyByTxRef || p.ExactAmount || p.ChargedAmount || len(p.FraudStatuses) > 0 || p.PaymentEntity != "" {
+		r.add("policy", "", "", fmt.Errorf("PolicyVerificationFailed: %T can't be verified against a policy", v))
+	}
+	return r
+}
+
+// verifyExactAmount is like verifyAmount but the amount must be exactly the expected amount
+func verifyExactAmount(want, got Money, currency string) error {
+	c, err := compareAmount(want, got, currency)
+	if err != nil {
+		return err
+	}
+	if c != 0 {
+		return fmt.Errorf("AmountVerificationFailed: expected exactly %s but got %s", want, got)
+	}
+	return nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(s ...string) string {
+	for _, v := range s {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// VerifyTransactionReport is like VerifyTransaction but it returns the report of the checks
+// The error is only for requests that fail, check the report for whether the transaction verified
+func (tvc *TxnVerificationChecklist) VerifyTransactionReport() (*TxnVerificationResponse, *VerificationReport, error) {
+	return tvc.VerifyTransactionReportContext(context.Background())
+}
+
+// VerifyTransactionReportContext is like VerifyTransactionReport but the request is bound to the given context
+func (tvc *TxnVerificationChecklist) VerifyTransactionReportContext(ctx context.Context) (*TxnVerificationResponse, *VerificationReport, error) {
+	return defaultClient().VerifyTransactionReportContext(ctx, tvc)
+}
+
+// VerifyTransactionReport is like VerifyTransaction but it returns the report of the checks
+// The error is only for requests that fail, check the report for whether the transaction verified
+func (c *Client) VerifyTransactionReport(tvc *TxnVerificationChecklist) (*TxnVerificationResponse, *VerificationReport, error) {
+	return c.VerifyTransactionReportContext(context.Background(), tvc)
+}
+
+// VerifyTransactionReportContext is like VerifyTransactionReport but the request is bound to the given context
+func (c *Client) VerifyTransactionReportContext(ctx context.Context, tvc *TxnVerificationChecklist) (*TxnVerificationResponse, *VerificationReport, error) {
+	resp, err := c.verifyTransaction(ctx, tvc)
+	if err != nil {
+		return resp, nil, err
+	}
+	return resp, tvc.Report(resp), nil
+}
+
+// VerifyXRequeryTransactionReport is like VerifyXRequeryTransaction but it returns the report of the checks
+// The error is only for requests that fail, check the report for whether the transaction verified
+func (tvc *TxnVerificationChecklist) VerifyXRequeryTransactionReport() (*XRQTxnVerificationResponse, *VerificationReport, error) {
+	return tvc.VerifyXRequeryTransactionReportContext(context.Background())
+}
+
+// VerifyXRequeryTransactionReportContext is like VerifyXRequeryTransactionReport but the request is bound to the given context
+func (tvc *TxnVerificationChecklist) VerifyXRequeryTransactionReportContext(ctx context.Context) (*XRQTxnVerificationResponse, *VerificationReport, error) {
+	return defaultClient().VerifyXRequeryTransactionReportContext(ctx, tvc)
+}
+
+// VerifyXRequeryTransactionReport is like VerifyXRequeryTransaction but it returns the report of the checks
+// The error is only for requests that fail, check the report for whether the transaction verified
+func (c *Client) VerifyXRequeryTransactionReport(tvc *TxnVerificationChecklist) (*XRQTxnVerificationResponse, *VerificationReport, error) {
+	return c.VerifyXRequeryTransactionReportContext(context.Background(), tvc)
+}
+
+// VerifyXRequeryTransactionReportContext is like VerifyXRequeryTransactionReport but the request is bound to the given context
+func (c *Client) VerifyXRequeryTransactionReportContext(ctx context.Context, tvc *TxnVerificationChecklist) (*XRQTxnVerificationResponse, *VerificationReport, error) {
+	resp, err := c.verifyXRequeryTransaction(ctx, tvc)
+	if err != nil {
+		return resp, nil, err
+	}
+	return resp, tvc.Report(resp), nil
+}
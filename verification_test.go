@@ -0,0 +1,193 @@
+package ravepay
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestTxnVerificationChecklist_Report(t *testing.T) {
+	resp := &XRQTxnVerificationResponse{
+		Status: "success",
+		Data: XRQTxnAttempt{
+			Txref:         "MXX-ASC-4578",
+			Flwref:        "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88",
+			Amount:        NewMoney(300000, ""),
+			Chargedamount: NewMoney(304200, ""),
+			Currency:      "NGN",
+			Chargecode:    "00",
+			Fraudstatus:   "ok",
+			Paymenttype:   "card",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		tvc        TxnVerificationChecklist
+		wantFailed []string
+	}{
+		{
+			name: "passes rave's recommended checks by flw ref",
+			tvc:  TxnVerificationChecklist{FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", Amount: NewMoney(250000, "NGN"), TransactionCurrency: "NGN"},
+		},
+		{
+			name: "verifies by tx ref if the checklist has no flw ref",
+			tvc:  TxnVerificationChecklist{Txref: "MXX-ASC-4578", Amount: NewMoney(300000, "NGN"), TransactionCurrency: "NGN"},
+		},
+		{
+			name: "verifies by the policy's reference",
+			tvc: TxnVerificationChecklist{
+				FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", TxRef: "MXX-ASC-4579", Amount: NewMoney(300000, "NGN"), TransactionCurrency: "NGN",
+				Policy: VerificationPolicy{By: VerifyByTxRef},
+			},
+			wantFailed: []string{CheckReference},
+		},
+		{
+			name: "fails an amount that isn't exact",
+			tvc: TxnVerificationChecklist{
+				FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", Amount: NewMoney(250000, "NGN"), TransactionCurrency: "NGN",
+				Policy: VerificationPolicy{ExactAmount: true},
+			},
+			wantFailed: []string{CheckAmount},
+		},
+		{
+			name: "compares the charged amount",
+			tvc: TxnVerificationChecklist{
+				FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", Amount: NewMoney(304200, "NGN"), TransactionCurrency: "NGN",
+				Policy: VerificationPolicy{ExactAmount: true, ChargedAmount: true},
+			},
+		},
+		{
+			name: "checks the fraud status and payment entity",
+			tvc: TxnVerificationChecklist{
+				FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", Amount: NewMoney(300000, "NGN"), TransactionCurrency: "NGN",
+				Policy: VerificationPolicy{FraudStatuses: []string{"OK"}, PaymentEntity: "card"},
+			},
+		},
+		{
+			name: "fails fraud statuses and payment entities that aren't allowed",
+			tvc: TxnVerificationChecklist{
+				FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88", Amount: NewMoney(300000, "NGN"), TransactionCurrency: "USD",
+				Policy: VerificationPolicy{FraudStatuses: []string{"none"}, PaymentEntity: "account"},
+			},
+			wantFailed: []string{CheckCurrency, CheckFraudStatus, CheckPaymentEntity},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.tvc.Report(resp)
+			var failed []string
+			for _, c := range r.Failed() {
+				failed = append(failed, c.Name)
+				if c.Err == nil {
+					t.Errorf("the failed %s check has no error", c.Name)
+				}
+			}
+			if !reflect.DeepEqual(failed, tt.wantFailed) {
+				t.Errorf("Report() failed %v, want %v\n%v", failed, tt.wantFailed, r.Checks)
+			}
+			if r.Passed() != (len(tt.wantFailed) == 0) {
+				t.Errorf("Report().Passed() = %v, want %v", r.Passed(), len(tt.wantFailed) == 0)
+			}
+			if len(r.Errors()) != len(tt.wantFailed) {
+				t.Errorf("Report().Errors() = %v, want %d errors", r.Errors(), len(tt.wantFailed))
+			}
+		})
+	}
+}
+
+func TestTxnVerificationChecklist_Report_checks(t *testing.T) {
+	resp := &TxnVerificationResponse{
+		Status: "success",
+		Data: txnVerificationResponseData{
+			TxRef:               "MXX-ASC-4578",
+			Amount:              NewMoney(300000, ""),
+			ChargedAmount:       NewMoney(304200, ""),
+			TransactionCurrency: "NGN",
+		},
+	}
+	tvc := &TxnVerificationChecklist{TxRef: "MXX-ASC-4578", Amount: NewMoney(300000, "NGN"), TransactionCurrency: "NGN"}
+	tvc.Policy = VerificationPolicy{ExactAmount: true, ChargedAmount: true}
+
+	r := tvc.Report(resp)
+	got, ok := r.Check(CheckAmount)
+	if !ok {
+		t.Fatalf("Report() has no amount check: %v", r.Checks)
+	}
+	want := VerificationCheck{Name: CheckAmount, Expected: "3000", Actual: "3042", Passed: false, Err: got.Err}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Report().Check(amount) = %+v, want %+v", got, want)
+	}
+	if got, want := got.String(), "amount: expected 3000 got 3042 (failed)"; got != want {
+		t.Errorf("VerificationCheck.String() = %s, want %s", got, want)
+	}
+	if _, ok := r.Check(CheckFraudStatus); ok {
+		t.Errorf("Report() has a fraud status check without the policy asking for it")
+	}
+}
+
+func TestTxnVerificationChecklist_Report_verifiable(t *testing.T) {
+	err := fmt.Errorf("generic error :)")
+	tvc := &TxnVerificationChecklist{FlwRef: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88"}
+
+	r := tvc.Report(testVerifiable{amountErr: err})
+	if got, want := r.Errors(), []error{err}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Report().Errors() = %v, want %v", got, want)
+	}
+
+	tvc.Policy.PaymentEntity = "card"
+	if r := tvc.Report(testVerifiable{}); r.Passed() {
+		t.Errorf("Report() passed a policy that can't be checked: %v", r.Checks)
+	}
+}
+
+func TestClient_VerifyXRequeryTransactionReport(t *testing.T) {
+	handler := &testServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	c := NewClient(WithBaseURL(server.URL))
+
+	tests := []struct {
+		name       string
+		respBody   string
+		policy     VerificationPolicy
+		wantErr    bool
+		wantPassed bool
+	}{
+		{
+			name:       "verifies the transaction by tx ref",
+			respBody:   xRQSuccessfulVerificationResponse,
+			policy:     VerificationPolicy{By: VerifyByTxRef, ExactAmount: true, FraudStatuses: []string{"ok"}},
+			wantPassed: true,
+		},
+		{
+			name:     "fails the transaction that doesn't pass the policy",
+			respBody: xRQSuccessfulVerificationResponse,
+			policy:   VerificationPolicy{By: VerifyByTxRef, PaymentEntity: "account"},
+		},
+		{
+			name:     "returns the error of failed requests",
+			respBody: noTransactionFoundVerifyPaymentResponse,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler.resp = []byte(tt.respBody)
+			tvc := c.NewXRQTxnVerificationChecklist(NewMoney(815000, "NGN"), "", "OH-AAED44", "NGN")
+			tvc.Policy = tt.policy
+
+			_, r, err := c.VerifyXRequeryTransactionReport(tvc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyXRequeryTransactionReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if r.Passed() != tt.wantPassed {
+				t.Errorf("VerifyXRequeryTransactionReport() passed = %v, want %v\n%v", r.Passed(), tt.wantPassed, r.Checks)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package ravepay
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// webhookHashHeader is the header rave sends the merchant's secret hash in
+const webhookHashHeader = "verif-hash"
+
+// ErrInvalidWebhookHash is returned when a webhook's verif-hash header doesn't match the secret hash
+var ErrInvalidWebhookHash = errors.New("ravepay: invalid webhook hash")
+
+// ErrNoVerificationChecklist is the verification error of charges VerifyCharge returns no checklist for
+var ErrNoVerificationChecklist = errors.New("ravepay: no checklist to verify the charge with")
+
+// ChargeCompletedEvent is the webhook event rave sends when a charge completes
+// It has the shape of the charge response data plus the charged entity
+type ChargeCompletedEvent struct {
+	EventType string `json:"event.type"`
+	chargeResponseData
+	Entity WebhookEntity `json:"entity"`
+}
+
+// WebhookEntity is the card or account charged in a webhook charge event
+type WebhookEntity struct {
+	Card6         string `json:"card6"`
+	CardLast4     string `json:"card_last4"`
+	AccountNumber string `json:"account_number"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+}
+
+// Card returns the charged card described by the entity
+func (e WebhookEntity) Card() Card {
+	return Card{
+		CardBIN:     e.Card6,
+		Last4digits: e.CardLast4,
+	}
+}
+
+// Account returns the charged account described by the entity
+func (e WebhookEntity) Account() Account {
+	return Account{
+		AccountNumber: e.AccountNumber,
+		FirstName:     e.FirstName,
+		LastName:      e.LastName,
+	}
+}
+
+// TransferCompletedEvent is the webhook event rave sends when a transfer completes
+type TransferCompletedEvent struct {
+	EventType string   `json:"event.type"`
+	Transfer  Transfer `json:"transfer"`
+}
+
+// SubscriptionCancelledEvent is the webhook event rave sends when a subscription is cancelled
+type SubscriptionCancelledEvent struct {
+	EventType    string       `json:"event.type"`
+	Subscription Subscription `json:"data"`
+}
+
+// WebhookHandler is an http.Handler for receiving rave's webhooks
+// It validates the verif-hash header against the secret hash, decodes the payload into a typed event
+// and dispatches it to the registered callback for the event
+// A callback returning an error makes the handler respond with a 500 so rave redelivers the event
+// Events without a registered callback are acknowledged and dropped
+type WebhookHandler struct {
+	// SecretHash is the secret hash set on the rave dashboard's webhook settings
+	SecretHash string
+
+	OnChargeCompleted       func(context.Context, *ChargeCompletedEvent) error
+	OnTransferCompleted     func(context.Context, *TransferCompletedEvent) error
+	OnSubscriptionCancelled func(context.Context, *SubscriptionCancelledEvent) error
+
+	// VerifyCharge, if set, returns the checklist for re-verifying a completed charge with rave
+	// OnChargeCompleted is only called for charges that pass the verification
+	// a nil checklist fails the verification with ErrNoVerificationChecklist
+	VerifyCharge func(*ChargeCompletedEvent) *TxnVerificationChecklist
+	// OnVerificationFailed, if set, is called with the charges that fail the re-verification
+	OnVerificationFailed func(context.Context, *ChargeCompletedEvent, []error)
+	// Client is used for re-verifying charges and logging, the default client is used if nil
+	Client *Client
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.validHash(r.Header.Get(webhookHashHeader)) {
+		h.client().log(r.Context(), LogLevelWarn, "rejected webhook", "err", ErrInvalidWebhookHash)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.client().log(r.Context(), LogLevelError, "error occured while reading webhook body", "err", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), body); err != nil {
+		h.client().log(r.Context(), LogLevelError, "error occured while handling webhook", "err", err)
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// client returns the handler's client, or the default client if it has none
+func (h *WebhookHandler) client() *Client {
+	if h.Client != nil {
+		return h.Client
+	}
+	return defaultClient()
+}
+
+func (h *WebhookHandler) validHash(hash string) bool {
+	return h.SecretHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(h.SecretHash)) == 1
+}
+
+func (h *WebhookHandler) dispatch(ctx context.Context, body []byte) error {
+	event := struct {
+		EventType string `json:"event.type"`
+	}{}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return err
+	}
+
+	switch eventType := strings.ToLower(event.EventType); {
+	case strings.HasPrefix(eventType, "transfer"):
+		if h.OnTransferCompleted == nil {
+			return nil
+		}
+		e := &TransferCompletedEvent{}
+		if err := json.Unmarshal(body, e); err != nil {
+			return err
+		}
+		return h.OnTransferCompleted(ctx, e)
+
+	case strings.HasPrefix(eventType, "subscription") && strings.Contains(eventType, "cancel"):
+		if h.OnSubscriptionCancelled == nil {
+			return nil
+		}
+		e := &SubscriptionCancelledEvent{}
+		if err := json.Unmarshal(body, e); err != nil {
+			return err
+		}
+		return h.OnSubscriptionCancelled(ctx, e)
+
+	case strings.HasSuffix(eventType, "transaction"):
+		if h.OnChargeCompleted == nil {
+			return nil
+		}
+		e := &ChargeCompletedEvent{}
+		if err := json.Unmarshal(body, e); err != nil {
+			return err
+		}
+		return h.chargeCompleted(ctx, e)
+	}
+
+	return nil
+}
+
+func (h *WebhookHandler) chargeCompleted(ctx context.Context, e *ChargeCompletedEvent) error {
+	if h.VerifyCharge == nil {
+		return h.OnChargeCompleted(ctx, e)
+	}
+
+	tvc := h.VerifyCharge(e)
+	if tvc == nil {
+		h.client().log(ctx, LogLevelWarn, "couldn't verify the completed charge", "err", ErrNoVerificationChecklist)
+		if h.OnVerificationFailed != nil {
+			h.OnVerificationFailed(ctx, e, []error{ErrNoVerificationChecklist})
+		}
+		return nil
+	}
+
+	_, errs := h.client().VerifyTransactionContext(ctx, tvc)
+	if len(errs) == 0 {
+		return h.OnChargeCompleted(ctx, e)
+	}
+
+	for _, err := range errs {
+		// the charge couldn't be verified at all, so have rave redeliver it
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.Err != nil || errors.Is(apiErr, ErrServer)) {
+			return err
+		}
+	}
+
+	if h.OnVerificationFailed != nil {
+		h.OnVerificationFailed(ctx, e, errs)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package ravepay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookHandler_ServeHTTP(t *testing.T) {
+	var gotCharge *ChargeCompletedEvent
+	var gotTransfer *TransferCompletedEvent
+	var gotSubscription *SubscriptionCancelledEvent
+
+	handler := &WebhookHandler{
+		SecretHash: "my-secret-hash",
+		OnChargeCompleted: func(ctx context.Context, e *ChargeCompletedEvent) error {
+			gotCharge = e
+			if e.TxRef == "fail-me" {
+				return errors.New("couldn't save charge")
+			}
+			return nil
+		},
+		OnTransferCompleted: func(ctx context.Context, e *TransferCompletedEvent) error {
+			gotTransfer = e
+			return nil
+		},
+		OnSubscriptionCancelled: func(ctx context.Context, e *SubscriptionCancelledEvent) error {
+			gotSubscription = e
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		hash       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "rejects requests with a wrong hash",
+			hash:       "wrong-hash",
+			body:       chargeCompletedWebhook,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "rejects malformed payloads",
+			hash:       "my-secret-hash",
+			body:       `{"event.type":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "dispatches charge completed events",
+			hash:       "my-secret-hash",
+			body:       chargeCompletedWebhook,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "dispatches transfer completed events",
+			hash:       "my-secret-hash",
+			body:       transferCompletedWebhook,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "dispatches subscription cancelled events",
+			hash:       "my-secret-hash",
+			body:       subscriptionCancelledWebhook,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "acknowledges unknown events",
+			hash:       "my-secret-hash",
+			body:       `{"event.type":"SOMETHING_NEW"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "responds with a server error if the callback fails",
+			hash:       "my-secret-hash",
+			body:       strings.Replace(chargeCompletedWebhook, "rave-pos-121775237991", "fail-me", 1),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/webhooks/rave", strings.NewReader(tt.body))
+			req.Header.Set("verif-hash", tt.hash)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("WebhookHandler.ServeHTTP() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	if gotCharge == nil || gotCharge.FlwRef != "FLW-MOCK-84ad9d55b88a1ee5f3a7a2fa4a3a1f1b" || gotCharge.Customer.Email != "user@example.com" || gotCharge.Entity.Card().Last4digits != "2950" {
+		t.Errorf("OnChargeCompleted got %+v", gotCharge)
+	}
+	if gotTransfer == nil || gotTransfer.Transfer.Reference != "transfer-ref-1" || gotTransfer.Transfer.Status != "SUCCESSFUL" {
+		t.Errorf("OnTransferCompleted got %+v", gotTransfer)
+	}
+	if gotSubscription == nil || gotSubscription.Subscription.ID != 2341 || gotSubscription.Subscription.Status != "cancelled" {
+		t.Errorf("OnSubscriptionCancelled got %+v", gotSubscription)
+	}
+}
+
+func TestWebhookHandler_VerifyCharge(t *testing.T) {
+	verifyHandler := &testServer{}
+	server := httptest.NewServer(verifyHandler)
+	defer server.Close()
+
+	var completed, failed int
+	handler := &WebhookHandler{
+		SecretHash: "my-secret-hash",
+		OnChargeCompleted: func(ctx context.Context, e *ChargeCompletedEvent) error {
+			completed++
+			return nil
+		},
+		VerifyCharge: func(e *ChargeCompletedEvent) *TxnVerificationChecklist {
+			return &TxnVerificationChecklist{
+				Amount:              NewMoney(30000, ""),
+				FlwRef:              "FLW-MOCK-09805abc71c5eebf80bb899183475fe3",
+				TransactionCurrency: "NGN",
+				VerificationURL:     server.URL,
+			}
+		},
+		OnVerificationFailed: func(ctx context.Context, e *ChargeCompletedEvent, errs []error) {
+			failed++
+		},
+	}
+
+	for _, resp := range []string{successfulCardVerifyPaymentResponse, successfulAccountVerifyPaymentResponse} {
+		verifyHandler.resp = []byte(resp)
+
+		req := httptest.NewRequest("POST", "/webhooks/rave", strings.NewReader(chargeCompletedWebhook))
+		req.Header.Set("verif-hash", "my-secret-hash")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("WebhookHandler.ServeHTTP() status = %d, want %d", w.Code, http.StatusOK)
+		}
+	}
+
+	if completed != 1 || failed != 1 {
+		t.Errorf("got %d completed and %d failed verifications, want 1 and 1", completed, failed)
+	}
+}
+
+func TestWebhookHandler_VerifyCharge_noChecklist(t *testing.T) {
+	var completed int
+	var failed []error
+	handler := &WebhookHandler{
+		SecretHash: "my-secret-hash",
+		OnChargeCompleted: func(ctx context.Context, e *ChargeCompletedEvent) error {
+			completed++
+			return nil
+		},
+		VerifyCharge: func(e *ChargeCompletedEvent) *TxnVerificationChecklist { return nil },
+		OnVerificationFailed: func(ctx context.Context, e *ChargeCompletedEvent, errs []error) {
+			failed = errs
+		},
+	}
+
+	req := httptest.NewRequest("POST", "/webhooks/rave", strings.NewReader(chargeCompletedWebhook))
+	req.Header.Set("verif-hash", "my-secret-hash")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("WebhookHandler.ServeHTTP() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if completed != 0 || len(failed) != 1 || !errors.Is(failed[0], ErrNoVerificationChecklist) {
+		t.Errorf("got %d completed charges and verification errors %v, want the charge to fail with %v", completed, failed, ErrNoVerificationChecklist)
+	}
+}
+
+var chargeCompletedWebhook = `{"id":1167451,"txRef":"rave-pos-121775237991","flwRef":"FLW-MOCK-84ad9d55b88a1ee5f3a7a2fa4a3a1f1b","orderRef":"URF_1577867664541_3572735","paymentPlan":null,"createdAt":"2020-01-01T08:34:24.000Z","amount":300,"charged_amount":300,"status":"successful","IP":"197.211.58.152","currency":"NGN","customer":{"id":312123,"phone":null,"fullName":"Anonymous customer","customertoken":null,"email":"user@example.com","createdAt":"2020-01-01T08:34:24.000Z","updatedAt":"2020-01-01T08:34:24.000Z","deletedAt":null,"AccountId":134},"entity":{"card6":"553188","card_last4":"2950"},"event.type":"CARD_TRANSACTION"}`
+
+var transferCompletedWebhook = `{"event.type":"Transfer","transfer":{"id":4569,"account_number":"0690000044","bank_code":"044","fullname":"Mercedes Daniel","date_created":"2018-06-20T09:41:47.000Z","currency":"NGN","debit_currency":"NGN","amount":500,"fee":45,"status":"SUCCESSFUL","reference":"transfer-ref-1","narration":"payout","complete_message":"Successful","requires_approval":0,"is_approved":1,"bank_name":"ACCESS BANK NIGERIA"}}`
+
+var subscriptionCancelledWebhook = `{"event.type":"Subscription_Cancelled","data":{"id":2341,"amount":1000,"customer":{"id":312123,"email":"user@example.com","AccountId":134},"customer_email":"user@example.com","plan":1018,"status":"cancelled","date_cancelled":"2020-02-01T08:34:24.000Z"}}`
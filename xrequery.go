@@ -0,0 +1,143 @@
+package ravepay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// XRQTxnAttempts are the attempts of a transaction in the order rave returned them
+type XRQTxnAttempts []XRQTxnAttempt
+
+// XRQTxnAttemptsResponse is rave's xrequery response listing every attempt of a transaction
+type XRQTxnAttemptsResponse struct {
+	Data    XRQTxnAttempts `json:"data"`
+	Message string         `json:"message"`
+	Status  string         `json:"status"`
+}
+
+// UnmarshalJSON decodes a data array of attempts, or a single attempt as rave responds when asked for the last attempt
+func (a *XRQTxnAttempts) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case len(b) == 0 || bytes.Equal(b, []byte("null")):
+		*a = nil
+		return nil
+	case b[0] == '{':
+		var attempt XRQTxnAttempt
+		if err := json.Unmarshal(b, &attempt); err != nil {
+			return err
+		}
+		*a = XRQTxnAttempts{attempt}
+		return nil
+	}
+
+	var list []XRQTxnAttempt
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// Latest returns the latest attempt i.e the one with the highest txid, it's false if there are no attempts
+func (a XRQTxnAttempts) Latest() (XRQTxnAttempt, bool) {
+	latest := -1
+	for i, attempt := range a {
+		if latest < 0 || attempt.Txid >= a[latest].Txid {
+			latest = i
+		}
+	}
+	if latest < 0 {
+		return XRQTxnAttempt{}, false
+	}
+	return a[latest], true
+}
+
+// FirstSuccessful returns the first successful attempt i.e the successful one with the lowest txid
+// it's false if no attempt succeeded
+func (a XRQTxnAttempts) FirstSuccessful() (XRQTxnAttempt, bool) {
+	first := -1
+	for i, attempt := range a {
+		if !attempt.successful() {
+			continue
+		}
+		if first < 0 || attempt.Txid < a[first].Txid {
+			first = i
+		}
+	}
+	if first < 0 {
+		return XRQTxnAttempt{}, false
+	}
+	return a[first], true
+}
+
+func (a XRQTxnAttempt) successful() bool {
+	return strings.EqualFold(a.Status, "successful")
+}
+
+// Latest returns the xrequery response of the latest attempt for verifying it, it's false if there are no attempts
+//
+//	resp, ok := attempts.Latest()
+//	errs := checklist.Verify(resp)
+func (resp *XRQTxnAttemptsResponse) Latest() (*XRQTxnVerificationResponse, bool) {
+	attempt, ok := resp.Data.Latest()
+	return resp.attempt(attempt), ok
+}
+
+// FirstSuccessful returns the xrequery response of the first successful attempt for verifying it
+// it's false if no attempt succeeded
+func (resp *XRQTxnAttemptsResponse) FirstSuccessful() (*XRQTxnVerificationResponse, bool) {
+	attempt, ok := resp.Data.FirstSuccessful()
+	return resp.attempt(attempt), ok
+}
+
+func (resp *XRQTxnAttemptsResponse) attempt(a XRQTxnAttempt) *XRQTxnVerificationResponse {
+	return &XRQTxnVerificationResponse{Data: a, Message: resp.Message, Status: resp.Status}
+}
+
+// UnmarshalJSON decodes the response
+// xrequery responds with a data array of every attempt of the transaction unless it's asked for the last attempt,
+// Data is the latest attempt of the array
+func (resp *XRQTxnVerificationResponse) UnmarshalJSON(b []byte) error {
+	var attempts XRQTxnAttemptsResponse
+	if err := json.Unmarshal(b, &attempts); err != nil {
+		return err
+	}
+	latest, _ := attempts.Latest()
+	*resp = *latest
+	return nil
+}
+
+// TransactionAttempts returns every attempt to pay for the transaction with the tx ref
+// https://flutterwavedevelopers.readme.io/v1.0/reference#xrequery-transaction-verification
+func TransactionAttempts(txRef string) (*XRQTxnAttemptsResponse, error) {
+	return TransactionAttemptsContext(context.Background(), txRef)
+}
+
+// TransactionAttemptsContext is like TransactionAttempts but the request is bound to the given context
+func TransactionAttemptsContext(ctx context.Context, txRef string) (*XRQTxnAttemptsResponse, error) {
+	return defaultClient().TransactionAttemptsContext(ctx, txRef)
+}
+
+// TransactionAttempts returns every attempt to pay for the transaction with the tx ref
+// Pick the attempt to verify with Latest or FirstSuccessful
+func (c *Client) TransactionAttempts(txRef string) (*XRQTxnAttemptsResponse, error) {
+	return c.TransactionAttemptsContext(context.Background(), txRef)
+}
+
+// TransactionAttemptsContext is like TransactionAttempts but the request is bound to the given context
+func (c *Client) TransactionAttemptsContext(ctx context.Context, txRef string) (*XRQTxnAttemptsResponse, error) {
+	if txRef == "" {
+		return &XRQTxnAttemptsResponse{}, ValidationErrors{&ValidationError{Field: "txref", Message: "is required"}}.err()
+	}
+
+	payload := &TxnVerificationChecklist{Txref: txRef, SECKEY: c.SecretKey}
+	resp := &XRQTxnAttemptsResponse{}
+	err := c.withRetries(ctx, func(ctx context.Context) error {
+		*resp = XRQTxnAttemptsResponse{}
+		return c.sendRequestAndParseResponse(ctx, "POST", c.buildURL(txnVerificationRequeryURL), payload, resp)
+	}, nil)
+	return resp, err
+}
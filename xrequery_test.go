@@ -0,0 +1,105 @@
+package ravepay
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+var xRQAttemptsVerificationResponse = `{"status":"success","message":"Tx Fetched","data":[` +
+	`{"txid":32460,"txref":"OH-AAED44","flwref":"FLW-MOCK-d4c1c4b3eb3f8ee0b4b3dc2e7a1e6a14","amount":8150,"currency":"NGN","chargecode":"RR-51","status":"failed","paymenttype":"card"},` +
+	`{"txid":32458,"txref":"OH-AAED44","flwref":"FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88","amount":8150,"currency":"NGN","chargecode":"00","status":"successful","paymenttype":"card"},` +
+	`{"txid":32459,"txref":"OH-AAED44","flwref":"FLW-MOCK-839c1abc23b6a4bbb9da807d54c5bbda","amount":8150,"currency":"NGN","chargecode":"00","status":"successful","paymenttype":"account"}]}`
+
+func TestXRQTxnAttempts(t *testing.T) {
+	tests := []struct {
+		name                string
+		body                string
+		wantLen             int
+		wantLatest          string
+		wantFirstSuccessful string
+	}{
+		{
+			name:                "picks from a data array",
+			body:                xRQAttemptsVerificationResponse,
+			wantLen:             3,
+			wantLatest:          "FLW-MOCK-d4c1c4b3eb3f8ee0b4b3dc2e7a1e6a14",
+			wantFirstSuccessful: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88",
+		},
+		{
+			name:                "decodes the single attempt of last attempt responses",
+			body:                xRQSuccessfulVerificationResponse,
+			wantLen:             1,
+			wantLatest:          "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88",
+			wantFirstSuccessful: "FLW-MOCK-5980e4f35eb54158fc296a1f22b1ff88",
+		},
+		{
+			name: "has no attempts for an empty data array",
+			body: `{"status":"success","message":"Tx Fetched","data":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp XRQTxnAttemptsResponse
+			if err := json.Unmarshal([]byte(tt.body), &resp); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if len(resp.Data) != tt.wantLen {
+				t.Errorf("got %d attempts, want %d", len(resp.Data), tt.wantLen)
+			}
+			latest, ok := resp.Latest()
+			if ok != (tt.wantLatest != "") || latest.Data.Flwref != tt.wantLatest {
+				t.Errorf("Latest() = %s, %v, want %s", latest.Data.Flwref, ok, tt.wantLatest)
+			}
+			if latest.Status != "success" {
+				t.Errorf("Latest().Status = %s, want the response's status", latest.Status)
+			}
+			first, ok := resp.FirstSuccessful()
+			if ok != (tt.wantFirstSuccessful != "") || first.Data.Flwref != tt.wantFirstSuccessful {
+				t.Errorf("FirstSuccessful() = %s, %v, want %s", first.Data.Flwref, ok, tt.wantFirstSuccessful)
+			}
+
+			var single XRQTxnVerificationResponse
+			if err := json.Unmarshal([]byte(tt.body), &single); err != nil {
+				t.Fatalf("json.Unmarshal() of the verification response error = %v", err)
+			}
+			if single.Data.Flwref != tt.wantLatest || single.Status != "success" {
+				t.Errorf("the verification response decoded %s %s, want the latest attempt %s", single.Status, single.Data.Flwref, tt.wantLatest)
+			}
+		})
+	}
+}
+
+func TestClient_TransactionAttempts(t *testing.T) {
+	handler := &testServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	c := NewClient(WithBaseURL(server.URL))
+
+	handler.resp = []byte(xRQAttemptsVerificationResponse)
+	resp, err := c.TransactionAttempts("OH-AAED44")
+	if err != nil {
+		t.Fatalf("TransactionAttempts() error = %v", err)
+	}
+	first, ok := resp.FirstSuccessful()
+	if !ok {
+		t.Fatalf("FirstSuccessful() = false")
+	}
+	tvc := &TxnVerificationChecklist{TxRef: "OH-AAED44", Amount: NewMoney(815000, "NGN"), TransactionCurrency: "NGN"}
+	if errs := tvc.Verify(first); len(errs) != 0 {
+		t.Errorf("Verify() of the first successful attempt errs = %v", errs)
+	}
+	latest, _ := resp.Latest()
+	if errs := tvc.Verify(latest); len(errs) != 1 {
+		t.Errorf("Verify() of the failed latest attempt errs = %v, want the charge response error", errs)
+	}
+
+	handler.resp = []byte(noTransactionFoundVerifyPaymentResponse)
+	if _, err := c.TransactionAttempts("OH-AAED44"); !errors.Is(err, ErrValidation) {
+		t.Errorf("TransactionAttempts() of an unknown tx ref error = %v, want %v", err, ErrValidation)
+	}
+	if _, err := c.TransactionAttempts(""); !errors.Is(err, ErrValidation) {
+		t.Errorf("TransactionAttempts() without a tx ref error = %v, want %v", err, ErrValidation)
+	}
+}